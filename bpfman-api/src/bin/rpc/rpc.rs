@@ -4,7 +4,8 @@ use bpfman::{
     add_program, get_program, list_programs, pull_bytecode, remove_program,
     types::{
         FentryProgram, FexitProgram, KprobeProgram, ListFilter, Location, Program, ProgramData,
-        TcProceedOn, TcProgram, TracepointProgram, UprobeProgram, XdpProceedOn, XdpProgram,
+        ProgramType, TcProceedOn, TcProgram, TracepointProgram, UprobeProgram, XdpProceedOn,
+        XdpProgram,
     },
 };
 use bpfman_api::v1::{
@@ -12,7 +13,7 @@ use bpfman_api::v1::{
     list_response::ListResult, FentryAttachInfo, FexitAttachInfo, GetRequest, GetResponse,
     KprobeAttachInfo, ListRequest, ListResponse, LoadRequest, LoadResponse, PullBytecodeRequest,
     PullBytecodeResponse, TcAttachInfo, TracepointAttachInfo, UnloadRequest, UnloadResponse,
-    UprobeAttachInfo, XdpAttachInfo,
+    UprobeAttachInfo, VersionRequest, VersionResponse, XdpAttachInfo,
 };
 use tonic::{Request, Response, Status};
 
@@ -181,7 +182,10 @@ impl Bpfman for BpfmanLoader {
     }
 
     async fn list(&self, request: Request<ListRequest>) -> Result<Response<ListResponse>, Status> {
-        let mut reply = ListResponse { results: vec![] };
+        let mut reply = ListResponse {
+            results: vec![],
+            next_page_token: String::new(),
+        };
 
         let filter = ListFilter::new(
             request.get_ref().program_type,
@@ -190,10 +194,21 @@ impl Bpfman for BpfmanLoader {
         );
 
         // Await the response
-        for r in list_programs(filter)
+        let programs = list_programs(filter)
             .await
             .map_err(|e| Status::aborted(format!("failed to list programs: {e}")))?
-        {
+            .into_iter()
+            .map(|p| p.get_data().get_id().map(|id| (id, p)))
+            .collect::<Result<Vec<_>, _>>()
+            .map_err(|e| Status::aborted(format!("failed to list programs: {e}")))?;
+        let (programs, next_page_token) = page(
+            programs,
+            request.get_ref().page_size,
+            &request.get_ref().page_token,
+        )?;
+        reply.next_page_token = next_page_token;
+
+        for (_, r) in programs {
             // Populate the response with the Program Info and the Kernel Info.
             let reply_entry = ListResult {
                 info: if let Program::Unsupported(_) = r {
@@ -222,11 +237,114 @@ impl Bpfman for BpfmanLoader {
             None => return Err(Status::aborted("Empty pull_bytecode request received")),
         };
 
-        pull_bytecode(image)
+        let details = pull_bytecode(image)
             .await
             .map_err(|e| Status::aborted(format!("{e}")))?;
 
-        let reply = PullBytecodeResponse {};
+        let reply = PullBytecodeResponse {
+            digest: details.digest,
+            labels: details.labels,
+        };
+        Ok(Response::new(reply))
+    }
+
+    async fn version(
+        &self,
+        _request: Request<VersionRequest>,
+    ) -> Result<Response<VersionResponse>, Status> {
+        // The program types load has attach info for.
+        let program_types = [
+            ProgramType::Xdp,
+            ProgramType::Tc,
+            ProgramType::Tracepoint,
+            ProgramType::Probe,
+            ProgramType::Tracing,
+        ]
+        .into_iter()
+        .map(u32::from)
+        .collect();
+
+        let reply = VersionResponse {
+            version: env!("CARGO_PKG_VERSION").to_string(),
+            program_types,
+        };
         Ok(Response::new(reply))
     }
 }
+
+/// Returns the page of programs, keyed by kernel ID, that follows page_token
+/// along with the token of the next page, which is empty on the last page.
+/// The token is the kernel ID of the last program returned, and a page_size
+/// of 0 returns every remaining program.
+fn page<T>(
+    mut programs: Vec<(u32, T)>,
+    page_size: u32,
+    page_token: &str,
+) -> Result<(Vec<(u32, T)>, String), Status> {
+    let page_size = page_size as usize;
+    programs.sort_by_key(|(id, _)| *id);
+    if !page_token.is_empty() {
+        let last = page_token
+            .parse::<u32>()
+            .map_err(|_| Status::aborted(format!("invalid page_token {page_token}")))?;
+        programs.retain(|(id, _)| *id > last);
+    }
+
+    let mut next_page_token = String::new();
+    if page_size > 0 && programs.len() > page_size {
+        programs.truncate(page_size);
+        next_page_token = programs[page_size - 1].0.to_string();
+    }
+    Ok((programs, next_page_token))
+}
+
+#[cfg(test)]
+mod test {
+    use tonic::Code;
+
+    use super::*;
+
+    fn ids(programs: &[(u32, ())]) -> Vec<u32> {
+        programs.iter().map(|(id, _)| *id).collect()
+    }
+
+    #[test]
+    fn test_page_all() {
+        let (programs, token) = page(vec![(3, ()), (1, ()), (2, ())], 0, "").unwrap();
+        assert_eq!(ids(&programs), vec![1, 2, 3]);
+        assert_eq!(token, "");
+    }
+
+    #[test]
+    fn test_page_token_boundary() {
+        let programs = vec![(10, ()), (11, ()), (12, ()), (13, ()), (14, ())];
+
+        let (first, token) = page(programs.clone(), 2, "").unwrap();
+        assert_eq!(ids(&first), vec![10, 11]);
+        assert_eq!(token, "11");
+
+        // The program named by the token isn't returned again.
+        let (second, token) = page(programs, 2, &token).unwrap();
+        assert_eq!(ids(&second), vec![12, 13]);
+        assert_eq!(token, "13");
+
+        // A token left by a program unloaded since resumes after its ID.
+        let (rest, token) = page(vec![(10, ()), (13, ()), (14, ())], 2, "12").unwrap();
+        assert_eq!(ids(&rest), vec![13, 14]);
+        assert_eq!(token, "");
+    }
+
+    #[test]
+    fn test_page_exactly_page_size_left() {
+        let (programs, token) = page(vec![(10, ()), (11, ()), (12, ())], 2, "10").unwrap();
+        assert_eq!(ids(&programs), vec![11, 12]);
+        assert_eq!(token, "");
+    }
+
+    #[test]
+    fn test_page_invalid_token() {
+        let err = page(vec![(10, ())], 2, "ten").unwrap_err();
+        assert_eq!(err.code(), Code::Aborted);
+        assert_eq!(err.message(), "invalid page_token ten");
+    }
+}
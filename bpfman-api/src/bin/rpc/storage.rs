@@ -43,6 +43,8 @@ const RTDIR_BPFMAN_CSI_FS: &str = "/run/bpfman/csi/fs";
 // Node Publish Volume Error code constant mirrored from: https://github.com/container-storage-interface/spec/blob/master/spec.md#nodepublishvolume-errors
 const NPV_NOT_FOUND: i32 = 5;
 const OWNER_READ_WRITE: u32 = 0o0750;
+const PIN_READ_WRITE: u32 = 0o0660;
+const PIN_READ_ONLY: u32 = 0o0440;
 
 pub struct StorageManager {
     csi_identity: CsiIdentity,
@@ -124,8 +126,8 @@ impl Node for CsiNode {
         let volume_id = &req.volume_id;
         let target_path = &req.target_path;
         let volume_context = &req.volume_context;
-        // TODO (astoycos) support readonly bpf pins.
         let read_only = &req.readonly;
+        let pin_mode = pin_mode(*read_only);
 
         debug!(
             "Received publish volume request with :\n\
@@ -227,23 +229,28 @@ impl Node for CsiNode {
 
                     // Ensure unprivileged container access to bpffs pins
                     if let Some(fs_group) = fs_group {
-                        debug!(
-                            "Setting GID and permissions of map {} to {fs_group} and 0660",
-                            map_path.display()
-                        );
+                        debug!("Setting GID of map {} to {fs_group}", map_path.display());
                         chown(&map_path, None, fs_group.parse().ok())?;
-                        set_file_permissions(&map_path, 0o0660)
                     };
+                    debug!(
+                        "Setting permissions of map {} to {pin_mode:o}",
+                        map_path.display()
+                    );
+                    set_file_permissions(&map_path, pin_mode);
                     Ok::<(), Status>(())
                 })?;
 
                 // mount the bpffs into the container
-                mount_fs_in_container(path.to_str().unwrap(), target_path).map_err(|e| {
-                    Status::new(
-                        NPV_NOT_FOUND.into(),
-                        format!("failed mounting bpffs {path:?} to container {target_path}: {e}"),
-                    )
-                })?;
+                mount_fs_in_container(path.to_str().unwrap(), target_path, *read_only).map_err(
+                    |e| {
+                        Status::new(
+                            NPV_NOT_FOUND.into(),
+                            format!(
+                                "failed mounting bpffs {path:?} to container {target_path}: {e}"
+                            ),
+                        )
+                    },
+                )?;
 
                 Ok(Response::new(NodePublishVolumeResponse {}))
             }
@@ -434,10 +441,76 @@ pub(crate) fn unmount(directory: &str) -> anyhow::Result<()> {
     umount(directory).with_context(|| format!("unable to unmount fs at {directory}"))
 }
 
-pub(crate) fn mount_fs_in_container(path: &str, target_path: &str) -> anyhow::Result<()> {
-    debug!("Mounting {path} at {target_path}");
+pub(crate) fn mount_fs_in_container(
+    path: &str,
+    target_path: &str,
+    read_only: bool,
+) -> anyhow::Result<()> {
+    debug!("Mounting {path} at {target_path}, read only: {read_only}");
     let flags = MsFlags::MS_BIND;
 
     mount::<str, str, str, str>(Some(path), target_path, None, flags, None)
-        .with_context(|| format!("unable to mount bpffs {path} in container at {target_path}"))
+        .with_context(|| format!("unable to mount bpffs {path} in container at {target_path}"))?;
+
+    // MS_RDONLY is ignored on the initial bind mount, it has to be applied
+    // with a remount.
+    if read_only {
+        let flags = MsFlags::MS_BIND | MsFlags::MS_REMOUNT | MsFlags::MS_RDONLY;
+        let remounted = mount::<str, str, str, str>(None, target_path, None, flags, None)
+            .with_context(|| format!("unable to remount {target_path} read only"));
+        if remounted.is_err() {
+            // Don't leave a writable mount behind in the container.
+            if let Err(e) = unmount(target_path) {
+                error!("{e:#}");
+            }
+        }
+        remounted?;
+    }
+    Ok(())
+}
+
+// Returns the mode of the map pins published in a volume. The kernel checks
+// pin permissions when a map is opened, so read-only pins keep an
+// unprivileged container from obtaining a writable map fd. They don't stop a
+// container running as root, whose CAP_DAC_OVERRIDE bypasses the mode.
+fn pin_mode(read_only: bool) -> u32 {
+    if read_only {
+        PIN_READ_ONLY
+    } else {
+        PIN_READ_WRITE
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use nix::{errno::Errno, unistd::Uid};
+
+    use super::*;
+
+    #[test]
+    fn read_only_pin_mode() {
+        assert_eq!(pin_mode(true), PIN_READ_ONLY);
+        assert_eq!(pin_mode(false), PIN_READ_WRITE);
+    }
+
+    #[test]
+    fn mount_fs_in_container_read_only() {
+        // Bind mounts require CAP_SYS_ADMIN.
+        if !Uid::effective().is_root() {
+            return;
+        }
+
+        let fs = tempfile::tempdir().unwrap();
+        let target = tempfile::tempdir().unwrap();
+        let target_path = target.path().to_str().unwrap();
+
+        mount_fs_in_container(fs.path().to_str().unwrap(), target_path, true).unwrap();
+        let written = std::fs::write(target.path().join("map"), b"");
+        unmount(target_path).unwrap();
+
+        assert_eq!(
+            written.unwrap_err().raw_os_error(),
+            Some(Errno::EROFS as i32)
+        );
+    }
 }
@@ -243,12 +243,20 @@ pub struct ListRequest {
         ::prost::alloc::string::String,
         ::prost::alloc::string::String,
     >,
+    /// 0 returns all programs
+    #[prost(uint32, tag = "4")]
+    pub page_size: u32,
+    #[prost(string, tag = "5")]
+    pub page_token: ::prost::alloc::string::String,
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct ListResponse {
     #[prost(message, repeated, tag = "3")]
     pub results: ::prost::alloc::vec::Vec<list_response::ListResult>,
+    /// empty on the last page
+    #[prost(string, tag = "4")]
+    pub next_page_token: ::prost::alloc::string::String,
 }
 /// Nested message and enum types in `ListResponse`.
 pub mod list_response {
@@ -269,7 +277,17 @@ pub struct PullBytecodeRequest {
 }
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
-pub struct PullBytecodeResponse {}
+pub struct PullBytecodeResponse {
+    /// manifest digest bpfman pulled, empty if the image was cached without one
+    #[prost(string, tag = "1")]
+    pub digest: ::prost::alloc::string::String,
+    /// labels from the image config
+    #[prost(map = "string, string", tag = "2")]
+    pub labels: ::std::collections::HashMap<
+        ::prost::alloc::string::String,
+        ::prost::alloc::string::String,
+    >,
+}
 #[allow(clippy::derive_partial_eq_without_eq)]
 #[derive(Clone, PartialEq, ::prost::Message)]
 pub struct GetRequest {
@@ -284,6 +302,17 @@ pub struct GetResponse {
     #[prost(message, optional, tag = "2")]
     pub kernel_info: ::core::option::Option<KernelProgramInfo>,
 }
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct VersionRequest {}
+#[allow(clippy::derive_partial_eq_without_eq)]
+#[derive(Clone, PartialEq, ::prost::Message)]
+pub struct VersionResponse {
+    #[prost(string, tag = "1")]
+    pub version: ::prost::alloc::string::String,
+    #[prost(uint32, repeated, tag = "2")]
+    pub program_types: ::prost::alloc::vec::Vec<u32>,
+}
 /// Generated client implementations.
 pub mod bpfman_client {
     #![allow(unused_variables, dead_code, missing_docs, clippy::let_unit_value)]
@@ -459,6 +488,28 @@ pub mod bpfman_client {
             req.extensions_mut().insert(GrpcMethod::new("bpfman.v1.Bpfman", "Get"));
             self.inner.unary(req, path, codec).await
         }
+        pub async fn version(
+            &mut self,
+            request: impl tonic::IntoRequest<super::VersionRequest>,
+        ) -> std::result::Result<
+            tonic::Response<super::VersionResponse>,
+            tonic::Status,
+        > {
+            self.inner
+                .ready()
+                .await
+                .map_err(|e| {
+                    tonic::Status::new(
+                        tonic::Code::Unknown,
+                        format!("Service was not ready: {}", e.into()),
+                    )
+                })?;
+            let codec = tonic::codec::ProstCodec::default();
+            let path = http::uri::PathAndQuery::from_static("/bpfman.v1.Bpfman/Version");
+            let mut req = request.into_request();
+            req.extensions_mut().insert(GrpcMethod::new("bpfman.v1.Bpfman", "Version"));
+            self.inner.unary(req, path, codec).await
+        }
     }
 }
 /// Generated server implementations.
@@ -491,6 +542,10 @@ pub mod bpfman_server {
             &self,
             request: tonic::Request<super::GetRequest>,
         ) -> std::result::Result<tonic::Response<super::GetResponse>, tonic::Status>;
+        async fn version(
+            &self,
+            request: tonic::Request<super::VersionRequest>,
+        ) -> std::result::Result<tonic::Response<super::VersionResponse>, tonic::Status>;
     }
     #[derive(Debug)]
     pub struct BpfmanServer<T: Bpfman> {
@@ -793,6 +848,50 @@ pub mod bpfman_server {
                     };
                     Box::pin(fut)
                 }
+                "/bpfman.v1.Bpfman/Version" => {
+                    #[allow(non_camel_case_types)]
+                    struct VersionSvc<T: Bpfman>(pub Arc<T>);
+                    impl<T: Bpfman> tonic::server::UnaryService<super::VersionRequest>
+                    for VersionSvc<T> {
+                        type Response = super::VersionResponse;
+                        type Future = BoxFuture<
+                            tonic::Response<Self::Response>,
+                            tonic::Status,
+                        >;
+                        fn call(
+                            &mut self,
+                            request: tonic::Request<super::VersionRequest>,
+                        ) -> Self::Future {
+                            let inner = Arc::clone(&self.0);
+                            let fut = async move {
+                                <T as Bpfman>::version(&inner, request).await
+                            };
+                            Box::pin(fut)
+                        }
+                    }
+                    let accept_compression_encodings = self.accept_compression_encodings;
+                    let send_compression_encodings = self.send_compression_encodings;
+                    let max_decoding_message_size = self.max_decoding_message_size;
+                    let max_encoding_message_size = self.max_encoding_message_size;
+                    let inner = self.inner.clone();
+                    let fut = async move {
+                        let inner = inner.0;
+                        let method = VersionSvc(inner);
+                        let codec = tonic::codec::ProstCodec::default();
+                        let mut grpc = tonic::server::Grpc::new(codec)
+                            .apply_compression_config(
+                                accept_compression_encodings,
+                                send_compression_encodings,
+                            )
+                            .apply_max_message_size_config(
+                                max_decoding_message_size,
+                                max_encoding_message_size,
+                            );
+                        let res = grpc.unary(method, req).await;
+                        Ok(res)
+                    };
+                    Box::pin(fut)
+                }
                 _ => {
                     Box::pin(async move {
                         Ok(
@@ -28,7 +28,7 @@ use crate::{
     },
     oci_utils::image_manager::ImageManager,
     types::{
-        BytecodeImage, Direction, ListFilter,
+        BytecodeImage, BytecodeImageDetails, Direction, ListFilter,
         ProbeType::{self, *},
         Program, ProgramData, ProgramType, PROGRAM_PREFIX,
     },
@@ -288,12 +288,13 @@ pub async fn get_program(id: u32) -> Result<Program, BpfmanError> {
     }
 }
 
-/// Pulls an ebpf bytecode image from a remote OCI container registry.
-pub async fn pull_bytecode(image: BytecodeImage) -> anyhow::Result<()> {
+/// Pulls an ebpf bytecode image from a remote OCI container registry and
+/// returns its digest and labels.
+pub async fn pull_bytecode(image: BytecodeImage) -> anyhow::Result<BytecodeImageDetails> {
     let (_, root_db) = &setup().await?;
     let image_manager = &mut init_image_manager().await;
 
-    image_manager
+    let (image_content_key, _) = image_manager
         .get_image(
             root_db,
             &image.image_url,
@@ -302,7 +303,7 @@ pub async fn pull_bytecode(image: BytecodeImage) -> anyhow::Result<()> {
             image.password.clone(),
         )
         .await?;
-    Ok(())
+    Ok(image_manager.get_image_details(root_db, &image_content_key)?)
 }
 
 pub(crate) async fn init_database(sled_config: SledConfig) -> Result<Db, BpfmanError> {
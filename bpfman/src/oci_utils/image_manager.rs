@@ -25,8 +25,8 @@ use tar::Archive;
 
 use crate::{
     oci_utils::{cosign::CosignVerifier, ImageError},
-    types::ImagePullPolicy,
-    utils::{sled_get, sled_insert},
+    types::{BytecodeImageDetails, ImagePullPolicy},
+    utils::{sled_get, sled_get_option, sled_insert},
 };
 
 const OCI_PROGRAMS_LABEL: &str = "io.ebpf.programs";
@@ -162,7 +162,7 @@ impl ImageManager {
 
         let auth = self.get_auth_for_registry(image.registry(), username, password);
 
-        let (image_manifest, _, config_contents) = self
+        let (image_manifest, image_digest, config_contents) = self
             .client
             .pull_manifest_and_config(&image.clone(), &auth)
             .await
@@ -179,6 +179,12 @@ impl ImageManager {
             ImageError::DatabaseError("failed to write to db".to_string(), e.to_string())
         })?;
 
+        let image_digest_key = base_key.to_string() + "digest";
+
+        sled_insert(root_db, &image_digest_key, image_digest.as_bytes()).map_err(|e| {
+            ImageError::DatabaseError("failed to write to db".to_string(), e.to_string())
+        })?;
+
         let config_sha = &image_manifest
             .config
             .digest
@@ -325,11 +331,38 @@ impl ImageManager {
         Ok(get_bytecode_from_gzip(f))
     }
 
-    fn load_image_meta(
+    /// Returns the digest and config labels of the image stored under
+    /// image_content_key.
+    pub(crate) fn get_image_details(
         &self,
         root_db: &Db,
         image_content_key: &str,
-    ) -> Result<ContainerImageMetadata, ImageError> {
+    ) -> Result<BytecodeImageDetails, ImageError> {
+        let digest = sled_get_option(root_db, &(image_content_key.to_string() + "digest"))
+            .map_err(|e| ImageError::DatabaseError("failed to read db".to_string(), e.to_string()))?
+            .map(|d| String::from_utf8_lossy(&d).into_owned())
+            .unwrap_or_default();
+
+        let image_config = self.load_image_config(root_db, image_content_key)?;
+
+        let labels = image_config["config"]["Labels"]
+            .as_object()
+            .map(|labels| {
+                labels
+                    .iter()
+                    .filter_map(|(k, v)| v.as_str().map(|v| (k.clone(), v.to_string())))
+                    .collect()
+            })
+            .unwrap_or_default();
+
+        Ok(BytecodeImageDetails { digest, labels })
+    }
+
+    fn load_image_config(
+        &self,
+        root_db: &Db,
+        image_content_key: &str,
+    ) -> Result<Value, ImageError> {
         let manifest = serde_json::from_str::<OciImageManifest>(
             std::str::from_utf8(
                 &sled_get(root_db, &(image_content_key.to_string() + "manifest.json")).map_err(
@@ -358,6 +391,16 @@ impl ImageManager {
 
         let image_config: Value =
             serde_json::from_str(file_content).expect("cannot parse image config from database");
+
+        Ok(image_config)
+    }
+
+    fn load_image_meta(
+        &self,
+        root_db: &Db,
+        image_content_key: &str,
+    ) -> Result<ContainerImageMetadata, ImageError> {
+        let image_config = self.load_image_config(root_db, image_content_key)?;
         debug!(
             "Raw container image config {}",
             &image_config["config"]["Labels"].to_string()
@@ -487,6 +530,31 @@ mod tests {
         assert!(!program_bytes.is_empty())
     }
 
+    #[tokio::test]
+    async fn image_pull_details() {
+        let root_db = init_database(get_db_config())
+            .await
+            .expect("Unable to open root database for unit test");
+        let mut mgr = ImageManager::new(true).await.unwrap();
+        let (image_content_key, _) = mgr
+            .get_image(
+                &root_db,
+                "quay.io/bpfman-bytecode/go-xdp-counter:latest",
+                ImagePullPolicy::Always,
+                None,
+                None,
+            )
+            .await
+            .expect("failed to pull bytecode");
+
+        let details = mgr
+            .get_image_details(&root_db, &image_content_key)
+            .expect("failed to get image details");
+
+        assert!(details.digest.starts_with("sha256:"));
+        assert!(details.labels.contains_key(OCI_PROGRAMS_LABEL));
+    }
+
     #[tokio::test]
     async fn image_pull_policy_never_failure() {
         let mut mgr = ImageManager::new(true).await.unwrap();
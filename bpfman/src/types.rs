@@ -99,6 +99,17 @@ pub struct BytecodeImage {
     pub password: Option<String>,
 }
 
+/// The digest and labels of a bytecode image bpfman has pulled.
+#[derive(Debug, Clone, Default)]
+pub struct BytecodeImageDetails {
+    /// The manifest digest bpfman pulled, which for a multi-arch image is the
+    /// manifest for the node's architecture. Empty for an image stored before
+    /// bpfman recorded it.
+    pub digest: String,
+    /// The labels from the image config.
+    pub labels: HashMap<String, String>,
+}
+
 impl BytecodeImage {
     pub fn new(
         image_url: String,
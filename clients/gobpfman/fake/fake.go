@@ -0,0 +1,310 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides an in-memory implementation of gobpfman.BpfmanClient
+// for unit testing code that talks to bpfman.
+package fake
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// MapPinDir is the directory under which map pin paths are reported.
+	MapPinDir = "/run/bpfman/fs/maps"
+	// FirstProgramID is the kernel ID assigned to the first loaded program.
+	FirstProgramID = 1000
+	// Version is the bpfman version reported by Version.
+	Version = "0.5.0"
+)
+
+// ProgramTypes are the program types reported by Version, as kernel program
+// type numbers.
+var ProgramTypes = []uint32{6, 3, 5, 2, 26}
+
+// ImageLabels are the image config labels reported by PullBytecode.
+var ImageLabels = map[string]string{"io.ebpf.programs": `{"pass":"xdp"}`, "io.ebpf.maps": "{}"}
+
+// ImageDigest returns the manifest digest PullBytecode reports for url, the
+// sha256 of the URL itself.
+func ImageDigest(url string) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(url)))
+}
+
+var _ gobpfman.BpfmanClient = &BpfmanClient{}
+
+// BpfmanClient is an in-memory gobpfman.BpfmanClient. Loaded programs get
+// sequential kernel IDs starting at FirstProgramID and are kept until
+// unloaded, and List returns them in kernel ID order, paged like bpfman's.
+// Like bpfman, it fails every request with codes.Aborted and the daemon's
+// error message. The zero value is not usable, use NewBpfmanClient.
+type BpfmanClient struct {
+	mu       sync.Mutex
+	nextID   uint32
+	programs map[uint32]*gobpfman.ListResponse_ListResult
+	pulled   []string
+	errs     map[string]error
+}
+
+func NewBpfmanClient() *BpfmanClient {
+	return &BpfmanClient{
+		nextID:   FirstProgramID,
+		programs: map[uint32]*gobpfman.ListResponse_ListResult{},
+		errs:     map[string]error{},
+	}
+}
+
+// SetError makes every following call to method ("Load", "Unload", "List",
+// "Get", "PullBytecode" or "Version") fail with err. A nil err clears it.
+func (f *BpfmanClient) SetError(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err == nil {
+		delete(f.errs, method)
+		return
+	}
+	f.errs[method] = err
+}
+
+// Programs returns a copy of the currently loaded programs keyed by kernel
+// ID.
+func (f *BpfmanClient) Programs() map[uint32]*gobpfman.ListResponse_ListResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	programs := make(map[uint32]*gobpfman.ListResponse_ListResult, len(f.programs))
+	for id, p := range f.programs {
+		programs[id] = proto.Clone(p).(*gobpfman.ListResponse_ListResult)
+	}
+	return programs
+}
+
+// PulledImages returns the image URLs passed to PullBytecode, in order.
+func (f *BpfmanClient) PulledImages() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]string(nil), f.pulled...)
+}
+
+func (f *BpfmanClient) Load(ctx context.Context, in *gobpfman.LoadRequest,
+	opts ...grpc.CallOption) (*gobpfman.LoadResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["Load"]; err != nil {
+		return nil, err
+	}
+	if in.GetBytecode() == nil {
+		return nil, status.Error(codes.Aborted, "missing bytecode info")
+	}
+
+	id := f.nextID
+	f.nextID++
+
+	mapPinPath := fmt.Sprintf("%s/%d", MapPinDir, id)
+	mapUsedBy := []string{fmt.Sprint(id)}
+	if in.MapOwnerId != nil {
+		owner, ok := f.programs[in.GetMapOwnerId()]
+		if !ok {
+			return nil, status.Error(codes.Aborted, "An error occurred. map_owner_id does not exists")
+		}
+		owner.Info.MapUsedBy = append(owner.Info.MapUsedBy, fmt.Sprint(id))
+		mapPinPath = owner.Info.MapPinPath
+		mapUsedBy = owner.Info.MapUsedBy
+	}
+
+	result := &gobpfman.ListResponse_ListResult{
+		Info: &gobpfman.ProgramInfo{
+			Name:       in.GetName(),
+			Bytecode:   in.GetBytecode(),
+			Attach:     in.GetAttach(),
+			GlobalData: in.GetGlobalData(),
+			MapOwnerId: in.MapOwnerId,
+			MapPinPath: mapPinPath,
+			MapUsedBy:  mapUsedBy,
+			Metadata:   in.GetMetadata(),
+		},
+		KernelInfo: &gobpfman.KernelProgramInfo{
+			Id:          id,
+			Name:        truncate(in.GetName(), 15),
+			ProgramType: in.GetProgramType(),
+			LoadedAt:    time.Now().Format(time.RFC3339),
+		},
+	}
+	f.programs[id] = proto.Clone(result).(*gobpfman.ListResponse_ListResult)
+
+	return &gobpfman.LoadResponse{
+		Info:       result.Info,
+		KernelInfo: result.KernelInfo,
+	}, nil
+}
+
+func (f *BpfmanClient) Unload(ctx context.Context, in *gobpfman.UnloadRequest,
+	opts ...grpc.CallOption) (*gobpfman.UnloadResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["Unload"]; err != nil {
+		return nil, err
+	}
+	p, ok := f.programs[in.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.Aborted,
+			"An error occurred. Program %d does not exist or was not created by bpfman", in.GetId())
+	}
+	if owner, ok := f.programs[p.Info.GetMapOwnerId()]; ok && p.Info.MapOwnerId != nil {
+		usedBy := owner.Info.MapUsedBy[:0]
+		for _, u := range owner.Info.MapUsedBy {
+			if u != fmt.Sprint(in.GetId()) {
+				usedBy = append(usedBy, u)
+			}
+		}
+		owner.Info.MapUsedBy = usedBy
+	}
+	delete(f.programs, in.GetId())
+
+	return &gobpfman.UnloadResponse{}, nil
+}
+
+func (f *BpfmanClient) List(ctx context.Context, in *gobpfman.ListRequest,
+	opts ...grpc.CallOption) (*gobpfman.ListResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["List"]; err != nil {
+		return nil, err
+	}
+
+	var after uint64
+	if in.GetPageToken() != "" {
+		var err error
+		after, err = strconv.ParseUint(in.GetPageToken(), 10, 32)
+		if err != nil {
+			return nil, status.Errorf(codes.Aborted, "invalid page_token %s", in.GetPageToken())
+		}
+	}
+
+	ids := make([]uint32, 0, len(f.programs))
+	for id := range f.programs {
+		if uint64(id) <= after {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	res := &gobpfman.ListResponse{}
+	for _, id := range ids {
+		p := f.programs[id]
+		if in.ProgramType != nil && p.KernelInfo.ProgramType != in.GetProgramType() {
+			continue
+		}
+		if !matchMetadata(p.Info.Metadata, in.GetMatchMetadata()) {
+			continue
+		}
+		if in.GetPageSize() > 0 && len(res.Results) == int(in.GetPageSize()) {
+			res.NextPageToken = strconv.FormatUint(uint64(res.Results[len(res.Results)-1].KernelInfo.Id), 10)
+			break
+		}
+		res.Results = append(res.Results, proto.Clone(p).(*gobpfman.ListResponse_ListResult))
+	}
+	return res, nil
+}
+
+func (f *BpfmanClient) PullBytecode(ctx context.Context, in *gobpfman.PullBytecodeRequest,
+	opts ...grpc.CallOption) (*gobpfman.PullBytecodeResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["PullBytecode"]; err != nil {
+		return nil, err
+	}
+	if in.GetImage() == nil {
+		return nil, status.Error(codes.Aborted, "Empty pull_bytecode request received")
+	}
+	f.pulled = append(f.pulled, in.GetImage().GetUrl())
+
+	return &gobpfman.PullBytecodeResponse{
+		Digest: ImageDigest(in.GetImage().GetUrl()),
+		Labels: maps.Clone(ImageLabels),
+	}, nil
+}
+
+func (f *BpfmanClient) Get(ctx context.Context, in *gobpfman.GetRequest,
+	opts ...grpc.CallOption) (*gobpfman.GetResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["Get"]; err != nil {
+		return nil, err
+	}
+	p, ok := f.programs[in.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.Aborted, "An error occurred. Program %d does not exist", in.GetId())
+	}
+	p = proto.Clone(p).(*gobpfman.ListResponse_ListResult)
+
+	return &gobpfman.GetResponse{
+		Info:       p.Info,
+		KernelInfo: p.KernelInfo,
+	}, nil
+}
+
+func (f *BpfmanClient) Version(ctx context.Context, in *gobpfman.VersionRequest,
+	opts ...grpc.CallOption) (*gobpfman.VersionResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := f.errs["Version"]; err != nil {
+		return nil, err
+	}
+	return &gobpfman.VersionResponse{
+		Version:      Version,
+		ProgramTypes: append([]uint32(nil), ProgramTypes...),
+	}, nil
+}
+
+func matchMetadata(metadata, match map[string]string) bool {
+	for k, v := range match {
+		if metadata[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// truncate mimics the kernel's limit on program name length.
+func truncate(name string, n int) string {
+	if len(name) > n {
+		return name[:n]
+	}
+	return name
+}
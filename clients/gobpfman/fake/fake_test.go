@@ -0,0 +1,161 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func load(t *testing.T, f *BpfmanClient, name string, mapOwner *uint32) uint32 {
+	t.Helper()
+	res, err := f.Load(context.Background(), &gobpfman.LoadRequest{
+		Bytecode: &gobpfman.BytecodeLocation{
+			Location: &gobpfman.BytecodeLocation_File{File: "/tmp/prog.o"},
+		},
+		Name:       name,
+		MapOwnerId: mapOwner,
+		Metadata:   map[string]string{"name": name},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res.GetKernelInfo().GetId()
+}
+
+func TestMapOwner(t *testing.T) {
+	f := NewBpfmanClient()
+	owner := load(t, f, "owner", nil)
+	user := load(t, f, "user", &owner)
+
+	programs := f.Programs()
+	ownerInfo, userInfo := programs[owner].GetInfo(), programs[user].GetInfo()
+	if want := fmt.Sprintf("%s/%d", MapPinDir, owner); ownerInfo.GetMapPinPath() != want ||
+		userInfo.GetMapPinPath() != want {
+		t.Errorf("map pin paths %q and %q, want both %q", ownerInfo.GetMapPinPath(), userInfo.GetMapPinPath(), want)
+	}
+	if want := []string{fmt.Sprint(owner), fmt.Sprint(user)}; !slices.Equal(ownerInfo.GetMapUsedBy(), want) {
+		t.Errorf("owner's maps used by %v, want %v", ownerInfo.GetMapUsedBy(), want)
+	}
+
+	if _, err := f.Unload(context.Background(), &gobpfman.UnloadRequest{Id: user}); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{fmt.Sprint(owner)}; !slices.Equal(f.Programs()[owner].GetInfo().GetMapUsedBy(), want) {
+		t.Errorf("after unloading the user, owner's maps used by %v, want %v",
+			f.Programs()[owner].GetInfo().GetMapUsedBy(), want)
+	}
+
+	missing := uint32(1)
+	_, err := f.Load(context.Background(), &gobpfman.LoadRequest{
+		Bytecode:   ownerInfo.GetBytecode(),
+		MapOwnerId: &missing,
+	})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("Load with an unknown map owner returned %v", err)
+	}
+}
+
+func TestSetError(t *testing.T) {
+	f := NewBpfmanClient()
+	boom := errors.New("boom")
+
+	f.SetError("List", boom)
+	if _, err := f.List(context.Background(), &gobpfman.ListRequest{}); err != boom {
+		t.Errorf("List returned %v, want %v", err, boom)
+	}
+	if _, err := f.Get(context.Background(), &gobpfman.GetRequest{Id: 1}); err == boom {
+		t.Error("SetError for List failed Get")
+	}
+
+	f.SetError("List", nil)
+	if _, err := f.List(context.Background(), &gobpfman.ListRequest{}); err != nil {
+		t.Errorf("List after clearing the error returned %v", err)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	f := NewBpfmanClient()
+	a := load(t, f, "a", nil)
+	load(t, f, "b", nil)
+
+	res, err := f.List(context.Background(), &gobpfman.ListRequest{MatchMetadata: map[string]string{"name": "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.GetResults()) != 1 || res.GetResults()[0].GetKernelInfo().GetId() != a {
+		t.Errorf("List by metadata returned %v, want only program %d", res.GetResults(), a)
+	}
+}
+
+func TestListPaging(t *testing.T) {
+	f := NewBpfmanClient()
+	var ids []uint32
+	for _, name := range []string{"a", "b", "c"} {
+		ids = append(ids, load(t, f, name, nil))
+	}
+
+	tests := []struct {
+		name      string
+		pageSize  uint32
+		pageToken string
+		wantIDs   []uint32
+		wantToken string
+	}{
+		{name: "unpaged", wantIDs: ids},
+		{name: "first page", pageSize: 2, wantIDs: ids[:2], wantToken: fmt.Sprint(ids[1])},
+		{name: "last page", pageSize: 2, pageToken: fmt.Sprint(ids[1]), wantIDs: ids[2:]},
+		{name: "exactly page size left", pageSize: 2, pageToken: fmt.Sprint(ids[0]), wantIDs: ids[1:]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := f.List(context.Background(), &gobpfman.ListRequest{PageSize: tt.pageSize, PageToken: tt.pageToken})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []uint32
+			for _, r := range res.GetResults() {
+				got = append(got, r.GetKernelInfo().GetId())
+			}
+			if !slices.Equal(got, tt.wantIDs) || res.GetNextPageToken() != tt.wantToken {
+				t.Errorf("List returned %v and token %q, want %v and %q", got, res.GetNextPageToken(), tt.wantIDs, tt.wantToken)
+			}
+		})
+	}
+
+	_, err := f.List(context.Background(), &gobpfman.ListRequest{PageSize: 2, PageToken: "a"})
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("List with an invalid page token returned %v", err)
+	}
+}
+
+func TestProgramsIsACopy(t *testing.T) {
+	f := NewBpfmanClient()
+	id := load(t, f, "prog", nil)
+
+	f.Programs()[id].Info.Name = "changed"
+	if name := f.Programs()[id].GetInfo().GetName(); name != "prog" {
+		t.Errorf("program name is %q after changing a copy", name)
+	}
+}
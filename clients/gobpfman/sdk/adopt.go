@@ -0,0 +1,98 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"bytes"
+	"context"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// FindLoaded returns the bpfman program that was loaded from an identical
+// LoadRequest, or nil if there is none, so a restarted caller can adopt it
+// instead of unloading and reloading it. Only programs whose metadata
+// contains all of req's metadata are considered.
+func (c *Client) FindLoaded(ctx context.Context, req *gobpfman.LoadRequest) (*gobpfman.ListResponse_ListResult, error) {
+	programType := ProgramType(req.GetProgramType())
+	results, err := c.ListByMetadata(ctx, &programType, req.GetMetadata())
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range results {
+		if matchesLoadRequest(r.GetInfo(), req) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+func matchesLoadRequest(info *gobpfman.ProgramInfo, req *gobpfman.LoadRequest) bool {
+	if info == nil || info.GetName() != req.GetName() {
+		return false
+	}
+	if (info.MapOwnerId == nil) != (req.MapOwnerId == nil) || info.GetMapOwnerId() != req.GetMapOwnerId() {
+		return false
+	}
+	if !equalBytecode(info.GetBytecode(), req.GetBytecode()) {
+		return false
+	}
+	if len(info.GetGlobalData()) != len(req.GetGlobalData()) {
+		return false
+	}
+	for k, v := range req.GetGlobalData() {
+		if !bytes.Equal(info.GetGlobalData()[k], v) {
+			return false
+		}
+	}
+	return equalAttach(info.GetAttach(), req.GetAttach())
+}
+
+// equalBytecode compares bytecode locations, ignoring image credentials,
+// which bpfman does not report back.
+func equalBytecode(loaded, want *gobpfman.BytecodeLocation) bool {
+	loadedImage, wantImage := loaded.GetImage(), want.GetImage()
+	if loadedImage == nil || wantImage == nil {
+		return loadedImage == wantImage && loaded.GetFile() == want.GetFile()
+	}
+	return loadedImage.GetUrl() == wantImage.GetUrl() &&
+		loadedImage.GetImagePullPolicy() == wantImage.GetImagePullPolicy()
+}
+
+// equalAttach compares attach info, ignoring the dispatcher position
+// assigned by bpfman and the proceed-on defaults it fills in when the
+// request left them empty.
+func equalAttach(loaded, want *gobpfman.AttachInfo) bool {
+	loaded = proto.Clone(loaded).(*gobpfman.AttachInfo)
+	want = proto.Clone(want).(*gobpfman.AttachInfo)
+
+	if l, w := loaded.GetXdpAttachInfo(), want.GetXdpAttachInfo(); l != nil && w != nil {
+		l.Position, w.Position = 0, 0
+		if len(w.ProceedOn) == 0 {
+			l.ProceedOn = nil
+		}
+	}
+	if l, w := loaded.GetTcAttachInfo(), want.GetTcAttachInfo(); l != nil && w != nil {
+		l.Position, w.Position = 0, 0
+		if len(w.ProceedOn) == 0 {
+			l.ProceedOn = nil
+		}
+	}
+	return proto.Equal(loaded, want)
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+func TestFindLoaded(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	c := New(f)
+	req := testLoadRequest("prog")
+	req.Metadata = map[string]string{"owner": "a"}
+	res, err := f.Load(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := c.FindLoaded(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found.GetKernelInfo().GetId() != res.GetKernelInfo().GetId() {
+		t.Errorf("FindLoaded returned %v, want program %d", found, res.GetKernelInfo().GetId())
+	}
+
+	other := testLoadRequest("prog")
+	other.Metadata = map[string]string{"owner": "a"}
+	other.Bytecode = FileBytecode("/tmp/other.o")
+	if found, err := c.FindLoaded(context.Background(), other); err != nil || found != nil {
+		t.Errorf("FindLoaded for other bytecode returned %v, %v, want nothing", found, err)
+	}
+}
+
+func TestEqualAttach(t *testing.T) {
+	xdp := func(position int32, proceedOn ...int32) *gobpfman.AttachInfo {
+		return &gobpfman.AttachInfo{
+			Info: &gobpfman.AttachInfo_XdpAttachInfo{
+				XdpAttachInfo: &gobpfman.XDPAttachInfo{
+					Priority:  50,
+					Iface:     "eth0",
+					Position:  position,
+					ProceedOn: proceedOn,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		loaded *gobpfman.AttachInfo
+		want   *gobpfman.AttachInfo
+		equal  bool
+	}{
+		{name: "identical", loaded: xdp(0, 2), want: xdp(0, 2), equal: true},
+		{name: "position assigned", loaded: xdp(3, 2), want: xdp(0, 2), equal: true},
+		{name: "default proceed-on", loaded: xdp(0, 2, 31), want: xdp(0), equal: true},
+		{name: "different proceed-on", loaded: xdp(0, 2), want: xdp(0, 1), equal: false},
+		{name: "different type", loaded: xdp(0), want: testLoadRequest("prog").GetAttach(), equal: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := equalAttach(tt.loaded, tt.want); got != tt.equal {
+				t.Errorf("equalAttach = %t, want %t", got, tt.equal)
+			}
+		})
+	}
+}
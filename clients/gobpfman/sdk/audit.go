@@ -0,0 +1,149 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// AuditRecord describes one Load or Unload call made to bpfman.
+type AuditRecord struct {
+	Time time.Time `json:"time"`
+	// Method is "Load" or "Unload".
+	Method string `json:"method"`
+	// Requester identifies who asked for the call, e.g. the *Program
+	// resource being reconciled. See WithAuditRequester.
+	Requester   string `json:"requester,omitempty"`
+	ProgramID   uint32 `json:"programId,omitempty"`
+	ProgramName string `json:"programName,omitempty"`
+	ProgramType uint32 `json:"programType,omitempty"`
+	// Bytecode is the image URL or file path the program was loaded from.
+	Bytecode string `json:"bytecode,omitempty"`
+	// BytecodeDigest is the sha256 of a bytecode file, when it is readable
+	// by the caller.
+	BytecodeDigest string          `json:"bytecodeDigest,omitempty"`
+	Attach         json.RawMessage `json:"attach,omitempty"`
+	Error          string          `json:"error,omitempty"`
+}
+
+// AuditSink receives audit records. Record is called after the call has
+// completed and must not block for long.
+type AuditSink interface {
+	Record(ctx context.Context, rec AuditRecord)
+}
+
+type auditRequesterKey struct{}
+
+// WithAuditRequester returns a context whose Load and Unload calls are
+// audited as made on behalf of requester.
+func WithAuditRequester(ctx context.Context, requester string) context.Context {
+	return context.WithValue(ctx, auditRequesterKey{}, requester)
+}
+
+// AuditInterceptor returns a unary client interceptor that records every Load
+// and Unload call to sink. Other calls are passed through untouched.
+func AuditInterceptor(sink AuditSink) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+
+		rec := AuditRecord{
+			Time:   time.Now().UTC(),
+			Method: path.Base(method),
+		}
+		rec.Requester, _ = ctx.Value(auditRequesterKey{}).(string)
+
+		switch r := req.(type) {
+		case *gobpfman.LoadRequest:
+			rec.ProgramName = r.GetName()
+			rec.ProgramType = r.GetProgramType()
+			rec.Bytecode, rec.BytecodeDigest = describeBytecode(r.GetBytecode())
+			if r.GetAttach() != nil {
+				if attach, mErr := protojson.Marshal(r.GetAttach()); mErr == nil {
+					rec.Attach = attach
+				}
+			}
+			if res, ok := reply.(*gobpfman.LoadResponse); ok && err == nil {
+				rec.ProgramID = res.GetKernelInfo().GetId()
+			}
+		case *gobpfman.UnloadRequest:
+			rec.ProgramID = r.GetId()
+		default:
+			return err
+		}
+
+		if err != nil {
+			rec.Error = err.Error()
+		}
+		sink.Record(ctx, rec)
+		return err
+	}
+}
+
+func describeBytecode(loc *gobpfman.BytecodeLocation) (string, string) {
+	if image := loc.GetImage(); image != nil {
+		return image.GetUrl(), ""
+	}
+
+	file := loc.GetFile()
+	if file == "" {
+		return "", ""
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return file, ""
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return file, ""
+	}
+	return file, "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// JSONAuditSink writes each record as a line of JSON.
+type JSONAuditSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONAuditSink returns a sink writing JSON lines to w, such as an
+// append-only audit file.
+func NewJSONAuditSink(w io.Writer) *JSONAuditSink {
+	return &JSONAuditSink{enc: json.NewEncoder(w)}
+}
+
+func (s *JSONAuditSink) Record(_ context.Context, rec AuditRecord) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// An audit sink has nowhere to report its own failures.
+	_ = s.enc.Encode(rec)
+}
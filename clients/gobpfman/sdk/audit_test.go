@@ -0,0 +1,146 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordSink keeps every audit record.
+type recordSink struct {
+	records []AuditRecord
+}
+
+func (s *recordSink) Record(_ context.Context, rec AuditRecord) {
+	s.records = append(s.records, rec)
+}
+
+func TestAuditInterceptor(t *testing.T) {
+	bytecode := filepath.Join(t.TempDir(), "prog.o")
+	if err := os.WriteFile(bytecode, []byte("bytecode"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	load := testLoadRequest("prog")
+	load.Bytecode = FileBytecode(bytecode)
+	loadErr := status.Error(codes.Aborted, "An error occurred. failed to load")
+
+	tests := []struct {
+		name   string
+		method string
+		req    any
+		err    error
+		want   AuditRecord
+	}{
+		{
+			name: "load", method: "/bpfman.v1.Bpfman/Load", req: load,
+			want: AuditRecord{Method: "Load", Requester: "xdp/app", ProgramID: 42, ProgramName: "prog",
+				ProgramType: uint32(Tracepoint), Bytecode: bytecode,
+				BytecodeDigest: fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("bytecode")))},
+		},
+		{
+			name: "failed load", method: "/bpfman.v1.Bpfman/Load", req: load, err: loadErr,
+			want: AuditRecord{Method: "Load", Requester: "xdp/app", ProgramName: "prog",
+				ProgramType: uint32(Tracepoint), Bytecode: bytecode,
+				BytecodeDigest: fmt.Sprintf("sha256:%x", sha256.Sum256([]byte("bytecode"))),
+				Error:          loadErr.Error()},
+		},
+		{
+			name: "unload", method: "/bpfman.v1.Bpfman/Unload", req: &gobpfman.UnloadRequest{Id: 7},
+			want: AuditRecord{Method: "Unload", Requester: "xdp/app", ProgramID: 7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := &recordSink{}
+			invoker := func(ctx context.Context, method string, req, reply any,
+				cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				if res, ok := reply.(*gobpfman.LoadResponse); ok && tt.err == nil {
+					res.KernelInfo = &gobpfman.KernelProgramInfo{Id: 42}
+				}
+				return tt.err
+			}
+
+			var reply any
+			_, isLoad := tt.req.(*gobpfman.LoadRequest)
+			if isLoad {
+				reply = &gobpfman.LoadResponse{}
+			} else {
+				reply = &gobpfman.UnloadResponse{}
+			}
+			ctx := WithAuditRequester(context.Background(), "xdp/app")
+			err := AuditInterceptor(sink)(ctx, tt.method, tt.req, reply, nil, invoker)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("interceptor returned %v, want %v", err, tt.err)
+			}
+
+			if len(sink.records) != 1 {
+				t.Fatalf("got %d records, want 1", len(sink.records))
+			}
+			rec := sink.records[0]
+			if rec.Time.IsZero() {
+				t.Error("record has no time")
+			}
+			if isLoad && len(rec.Attach) == 0 {
+				t.Error("load record has no attach info")
+			}
+			rec.Time, rec.Attach = time.Time{}, nil
+			if !reflect.DeepEqual(rec, tt.want) {
+				t.Errorf("got record %+v, want %+v", rec, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditInterceptorPassesThrough(t *testing.T) {
+	sink := &recordSink{}
+	req, reply := &gobpfman.ListRequest{}, &gobpfman.ListResponse{}
+	listErr := status.Error(codes.Aborted, "An error occurred. failed to list")
+
+	called := false
+	invoker := func(ctx context.Context, method string, gotReq, gotReply any,
+		cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		called = true
+		if method != "/bpfman.v1.Bpfman/List" || gotReq != req || gotReply != reply {
+			t.Errorf("invoker got %s %v %v, want the List call unchanged", method, gotReq, gotReply)
+		}
+		return listErr
+	}
+
+	err := AuditInterceptor(sink)(context.Background(), "/bpfman.v1.Bpfman/List", req, reply, nil, invoker)
+	if !called {
+		t.Fatal("List was not invoked")
+	}
+	if err != listErr {
+		t.Errorf("interceptor returned %v, want %v", err, listErr)
+	}
+	if len(sink.records) != 0 {
+		t.Errorf("List was audited: %+v", sink.records)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+const (
+	DefaultBatchConcurrency = 8
+)
+
+// LoadBatch issues reqs concurrently, at most concurrency at a time (zero
+// uses DefaultBatchConcurrency), and returns the responses in request order.
+// bpfman has no batched Load RPC, so this cuts the latency of N round-trips
+// rather than their number. If any load fails, the programs that were loaded
+// are unloaded again and the combined error is returned.
+func (c *Client) LoadBatch(ctx context.Context, reqs []*gobpfman.LoadRequest,
+	concurrency int) ([]*gobpfman.LoadResponse, error) {
+	responses := make([]*gobpfman.LoadResponse, len(reqs))
+	errs := make([]error, len(reqs))
+
+	forEach(len(reqs), concurrency, func(i int) {
+		res, err := c.bpfman.Load(ctx, reqs[i])
+		if err != nil {
+			errs[i] = fmt.Errorf("failed to load program %q: %w", reqs[i].GetName(), err)
+			return
+		}
+		responses[i] = res
+	})
+
+	err := errors.Join(errs...)
+	if err == nil {
+		return responses, nil
+	}
+
+	var loaded []uint32
+	for _, res := range responses {
+		if id := res.GetKernelInfo().GetId(); id != 0 {
+			loaded = append(loaded, id)
+		}
+	}
+	if unloadErr := c.UnloadBatch(ctx, loaded, concurrency); unloadErr != nil {
+		err = errors.Join(err, fmt.Errorf("rollback failed: %w", unloadErr))
+	}
+	return nil, err
+}
+
+// UnloadBatch unloads the programs with the given kernel IDs concurrently, at
+// most concurrency at a time, and returns the combined error of the unloads
+// that failed.
+func (c *Client) UnloadBatch(ctx context.Context, ids []uint32, concurrency int) error {
+	errs := make([]error, len(ids))
+
+	forEach(len(ids), concurrency, func(i int) {
+		errs[i] = c.Unload(ctx, ids[i])
+	})
+
+	return errors.Join(errs...)
+}
+
+// forEach calls fn for 0..n-1 from at most concurrency goroutines and waits
+// for all of them to return.
+func forEach(n, concurrency int, fn func(i int)) {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, concurrency)
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
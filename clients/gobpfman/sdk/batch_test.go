@@ -0,0 +1,139 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loadStub loads and unloads programs like bpfman, failing a Load without
+// bytecode.
+type loadStub struct {
+	gobpfman.BpfmanClient
+
+	mu     sync.Mutex
+	nextID uint32
+	loaded map[uint32]string
+}
+
+func newLoadStub() *loadStub {
+	return &loadStub{nextID: 1000, loaded: map[uint32]string{}}
+}
+
+func (s *loadStub) Load(ctx context.Context, in *gobpfman.LoadRequest,
+	opts ...grpc.CallOption) (*gobpfman.LoadResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if in.GetBytecode() == nil {
+		return nil, status.Error(codes.Aborted, "missing bytecode info")
+	}
+	id := s.nextID
+	s.nextID++
+	s.loaded[id] = in.GetName()
+	return &gobpfman.LoadResponse{
+		Info:       &gobpfman.ProgramInfo{Name: in.GetName()},
+		KernelInfo: &gobpfman.KernelProgramInfo{Id: id},
+	}, nil
+}
+
+func (s *loadStub) Unload(ctx context.Context, in *gobpfman.UnloadRequest,
+	opts ...grpc.CallOption) (*gobpfman.UnloadResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.loaded[in.GetId()]; !ok {
+		return nil, status.Errorf(codes.Aborted,
+			"An error occurred. Program %d does not exist or was not created by bpfman", in.GetId())
+	}
+	delete(s.loaded, in.GetId())
+	return &gobpfman.UnloadResponse{}, nil
+}
+
+func testLoadRequest(name string) *gobpfman.LoadRequest {
+	return &gobpfman.LoadRequest{
+		Bytecode:    FileBytecode("/tmp/prog.o"),
+		Name:        name,
+		ProgramType: uint32(Tracepoint),
+		Attach: &gobpfman.AttachInfo{
+			Info: &gobpfman.AttachInfo_TracepointAttachInfo{
+				TracepointAttachInfo: &gobpfman.TracepointAttachInfo{Tracepoint: "syscalls/sys_enter_kill"},
+			},
+		},
+	}
+}
+
+func TestLoadBatch(t *testing.T) {
+	s := newLoadStub()
+	reqs := []*gobpfman.LoadRequest{testLoadRequest("a"), testLoadRequest("b"), testLoadRequest("c")}
+
+	responses, err := New(s).LoadBatch(context.Background(), reqs, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, res := range responses {
+		if name := res.GetInfo().GetName(); name != reqs[i].GetName() {
+			t.Errorf("response %d is for %q, want %q", i, name, reqs[i].GetName())
+		}
+	}
+	if n := len(s.loaded); n != 3 {
+		t.Errorf("%d programs loaded, want 3", n)
+	}
+}
+
+func TestLoadBatchRollback(t *testing.T) {
+	s := newLoadStub()
+	bad := testLoadRequest("bad")
+	bad.Bytecode = nil
+	reqs := []*gobpfman.LoadRequest{testLoadRequest("a"), bad, testLoadRequest("c")}
+
+	responses, err := New(s).LoadBatch(context.Background(), reqs, 0)
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("LoadBatch returned %v, want the Aborted load error", err)
+	}
+	if responses != nil {
+		t.Errorf("LoadBatch returned %d responses, want none", len(responses))
+	}
+	if n := len(s.loaded); n != 0 {
+		t.Errorf("%d programs left loaded, want the batch rolled back", n)
+	}
+}
+
+func TestUnloadBatch(t *testing.T) {
+	s := newLoadStub()
+	c := New(s)
+	responses, err := c.LoadBatch(context.Background(), []*gobpfman.LoadRequest{testLoadRequest("a")}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := responses[0].GetKernelInfo().GetId()
+	err = c.UnloadBatch(context.Background(), []uint32{id, id + 100}, 0)
+	if status.Code(err) != codes.Aborted {
+		t.Errorf("UnloadBatch returned %v, want the error for the unknown program", err)
+	}
+	if n := len(s.loaded); n != 0 {
+		t.Errorf("%d programs left loaded, want 0", n)
+	}
+}
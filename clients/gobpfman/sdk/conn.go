@@ -0,0 +1,189 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	DefaultSocketPath = "/run/bpfman-sock/bpfman.sock"
+	// EndpointEnv is the environment variable EndpointFromEnv reads.
+	EndpointEnv = "BPFMAN_ENDPOINT"
+)
+
+// Connect creates a client connection to the bpfman unix socket at
+// socketPath, or DefaultSocketPath if empty. Calls on the connection wait for
+// bpfman to become ready, so a bpfman restart delays calls instead of failing
+// them with codes.Unavailable; callers bound the wait with their context.
+//
+// opts are applied after the SDK's own options. To trace bpfman calls with
+// OpenTelemetry, pass grpc.WithStatsHandler(otelgrpc.NewClientHandler()).
+func Connect(socketPath string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	if socketPath == "" {
+		socketPath = DefaultSocketPath
+	}
+
+	dialOpts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	}
+	dialOpts = append(dialOpts, opts...)
+
+	conn, err := grpc.NewClient(fmt.Sprintf("unix://%s", socketPath), dialOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create connection to %s: %w", socketPath, err)
+	}
+	return conn, nil
+}
+
+// EndpointFromEnv returns the bpfman endpoint set in EndpointEnv, or the
+// default unix socket if it is unset.
+func EndpointFromEnv() string {
+	if endpoint := os.Getenv(EndpointEnv); endpoint != "" {
+		return endpoint
+	}
+	return "unix://" + DefaultSocketPath
+}
+
+// ConnectEndpoint creates a client connection to bpfman at endpoint, a unix
+// socket given as "unix:///path" or an absolute "/path". bpfman only serves
+// its API on a unix socket, so other endpoints are rejected.
+func ConnectEndpoint(endpoint string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	switch {
+	case endpoint == "":
+		return Connect("", opts...)
+	case strings.HasPrefix(endpoint, "unix://"):
+		return Connect(strings.TrimPrefix(endpoint, "unix://"), opts...)
+	case strings.HasPrefix(endpoint, "/"):
+		return Connect(endpoint, opts...)
+	}
+	return nil, fmt.Errorf("unsupported bpfman endpoint %q, bpfman only listens on a unix socket", endpoint)
+}
+
+// DialConfig tunes the transport of a bpfman connection. Zero values keep the
+// gRPC defaults.
+type DialConfig struct {
+	// KeepaliveTime is the idle time after which a keepalive ping is sent.
+	KeepaliveTime time.Duration
+	// KeepaliveTimeout is how long to wait for a ping ack before the
+	// connection is considered broken.
+	KeepaliveTimeout time.Duration
+	// KeepalivePermitWithoutStream sends pings even when no call is active.
+	KeepalivePermitWithoutStream bool
+	// MaxRecvMsgSize and MaxSendMsgSize bound message sizes in bytes. Busy
+	// nodes may need a larger MaxRecvMsgSize for List responses.
+	MaxRecvMsgSize int
+	MaxSendMsgSize int
+	// DialTimeout is the minimum time given to each connection attempt.
+	DialTimeout time.Duration
+}
+
+// DialOptions returns the grpc.DialOptions implementing cfg, for use with Connect.
+func (cfg DialConfig) DialOptions() []grpc.DialOption {
+	var opts []grpc.DialOption
+
+	if cfg.KeepaliveTime > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.KeepaliveTime,
+			Timeout:             cfg.KeepaliveTimeout,
+			PermitWithoutStream: cfg.KeepalivePermitWithoutStream,
+		}))
+	}
+
+	var callOpts []grpc.CallOption
+	if cfg.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(cfg.MaxRecvMsgSize))
+	}
+	if cfg.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(cfg.MaxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+
+	if cfg.DialTimeout > 0 {
+		opts = append(opts, grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff:           backoff.DefaultConfig,
+			MinConnectTimeout: cfg.DialTimeout,
+		}))
+	}
+
+	return opts
+}
+
+// ConnMonitor tracks the connectivity state of a bpfman connection and keeps
+// it connecting while bpfman is away.
+type ConnMonitor struct {
+	conn     *grpc.ClientConn
+	ready    atomic.Bool
+	onChange func(connectivity.State)
+}
+
+// MonitorConn starts watching conn until ctx is done. onChange, if not nil,
+// is called from the monitor goroutine on every state transition, e.g. to
+// flip a readiness gauge.
+func MonitorConn(ctx context.Context, conn *grpc.ClientConn, onChange func(connectivity.State)) *ConnMonitor {
+	m := &ConnMonitor{
+		conn:     conn,
+		onChange: onChange,
+	}
+	m.update(conn.GetState())
+	go m.run(ctx)
+	return m
+}
+
+// Ready reports whether the connection to bpfman is currently established.
+func (m *ConnMonitor) Ready() bool {
+	return m.ready.Load()
+}
+
+func (m *ConnMonitor) run(ctx context.Context) {
+	for {
+		state := m.conn.GetState()
+		if state == connectivity.Shutdown {
+			return
+		}
+		if state == connectivity.Idle {
+			// Leave idle straight away so a restarted bpfman is picked up
+			// before the next call rather than by it.
+			m.conn.Connect()
+		}
+		if !m.conn.WaitForStateChange(ctx, state) {
+			return
+		}
+		m.update(m.conn.GetState())
+	}
+}
+
+func (m *ConnMonitor) update(state connectivity.State) {
+	m.ready.Store(state == connectivity.Ready)
+	if m.onChange != nil {
+		m.onChange(state)
+	}
+}
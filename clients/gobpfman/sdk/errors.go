@@ -0,0 +1,134 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// FailureReason classifies why a bpfman call failed, so callers can report
+// distinct conditions instead of one blanket failure.
+type FailureReason string
+
+const (
+	ReasonUnknown          FailureReason = "Unknown"
+	ReasonUnavailable      FailureReason = "BpfmanUnavailable"
+	ReasonVerifierFailure  FailureReason = "VerifierFailure"
+	ReasonImagePullFailure FailureReason = "ImagePullFailure"
+	ReasonPermissionDenied FailureReason = "PermissionDenied"
+	ReasonAlreadyAttached  FailureReason = "AlreadyAttached"
+	ReasonNoAttachSlot     FailureReason = "NoAttachSlot"
+	ReasonProgramNotFound  FailureReason = "ProgramNotFound"
+	ReasonInvalidRequest   FailureReason = "InvalidRequest"
+	ReasonMapIncompatible  FailureReason = "MapIncompatible"
+)
+
+// bpfman returns Status::aborted for every failed operation, so the reason
+// has to be recovered from the daemon's error text. These fragments match the
+// messages of bpfman's BpfmanError and ImageError and of aya's ProgramError.
+var failureMessages = []struct {
+	reason    FailureReason
+	fragments []string
+}{
+	{ReasonVerifierFailure, []string{"Verifier output", "BPF_PROG_LOAD syscall failed"}},
+	{ReasonImagePullFailure, []string{"Failed to pull bytecode Image", "Failed to Parse bytecode Image URL",
+		"BytecodeImage not found", "Failed to extract bytecode from Image"}},
+	{ReasonPermissionDenied, []string{"Operation not permitted", "Permission denied"}},
+	{ReasonAlreadyAttached, []string{"already attached"}},
+	{ReasonNoAttachSlot, []string{"No room to attach program"}},
+	{ReasonProgramNotFound, []string{"Unable to find a valid program with function name", "not found in bytecode image"}},
+	{ReasonInvalidRequest, []string{"is not a valid", "Invalid Interface", "missing bytecode", "missing attach info",
+		"missing location", "failed to parse proceed_on"}},
+}
+
+// ClassifyError returns the FailureReason for an error from a bpfman call
+// along with the daemon's message, which callers should preserve when
+// reporting the failure. A nil error yields an empty reason.
+func ClassifyError(err error) (FailureReason, string) {
+	if err == nil {
+		return "", ""
+	}
+	if errors.Is(err, ErrMapIncompatible) {
+		return ReasonMapIncompatible, err.Error()
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return ReasonUnknown, err.Error()
+	}
+
+	switch st.Code() {
+	case codes.Unavailable:
+		return ReasonUnavailable, st.Message()
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return ReasonPermissionDenied, st.Message()
+	case codes.InvalidArgument:
+		return ReasonInvalidRequest, st.Message()
+	}
+
+	for _, m := range failureMessages {
+		for _, f := range m.fragments {
+			if strings.Contains(st.Message(), f) {
+				return m.reason, st.Message()
+			}
+		}
+	}
+	return ReasonUnknown, st.Message()
+}
+
+const (
+	verifierOutputMarker = "Verifier output:"
+	// DefaultVerifierLogSize is a size that fits comfortably in a
+	// Kubernetes status field or Event message.
+	DefaultVerifierLogSize = 4096
+)
+
+// VerifierLog returns the kernel verifier log carried by a failed Load, or
+// "" if err isn't a verifier rejection. Logs longer than maxLen bytes (zero
+// uses DefaultVerifierLogSize) keep their tail, where the verifier reports
+// the failing instruction.
+func VerifierLog(err error, maxLen int) string {
+	if err == nil {
+		return ""
+	}
+	if maxLen <= 0 {
+		maxLen = DefaultVerifierLogSize
+	}
+
+	msg := err.Error()
+	if st, ok := status.FromError(err); ok {
+		msg = st.Message()
+	}
+	i := strings.Index(msg, verifierOutputMarker)
+	if i < 0 {
+		return ""
+	}
+
+	log := strings.TrimSpace(msg[i+len(verifierOutputMarker):])
+	if len(log) > maxLen {
+		if maxLen > 3 {
+			log = "..." + log[len(log)-maxLen+3:]
+		} else {
+			log = log[len(log)-maxLen:]
+		}
+	}
+	return log
+}
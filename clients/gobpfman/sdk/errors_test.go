@@ -0,0 +1,73 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want FailureReason
+	}{
+		{name: "nil", err: nil, want: ""},
+		{name: "missing bytecode", err: status.Error(codes.Aborted, "missing bytecode info"), want: ReasonInvalidRequest},
+		{name: "unknown program", err: status.Error(codes.Aborted,
+			"An error occurred. Program 1 does not exist or was not created by bpfman"), want: ReasonUnknown},
+		{name: "unavailable", err: status.Error(codes.Unavailable, "connection refused"), want: ReasonUnavailable},
+		{name: "verifier", err: status.Error(codes.Aborted,
+			"An error occurred. Verifier output: 0: (b7) r0 = 0\nR0 !read_ok"), want: ReasonVerifierFailure},
+		{name: "no attach slot", err: status.Error(codes.Aborted,
+			"An error occurred. No room to attach program. Please remove one and try again."), want: ReasonNoAttachSlot},
+		{name: "image pull", err: status.Error(codes.Aborted,
+			"An error occurred. Failed to pull bytecode Image: 401"), want: ReasonImagePullFailure},
+		{name: "map incompatible", err: fmt.Errorf("owner 3: %w", ErrMapIncompatible), want: ReasonMapIncompatible},
+		{name: "not a status", err: fmt.Errorf("boom"), want: ReasonUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, msg := ClassifyError(tt.err)
+			if reason != tt.want {
+				t.Errorf("ClassifyError(%v) = %s, want %s", tt.err, reason, tt.want)
+			}
+			if tt.err != nil && msg == "" {
+				t.Error("ClassifyError dropped the message")
+			}
+		})
+	}
+}
+
+func TestVerifierLog(t *testing.T) {
+	err := status.Error(codes.Aborted, "An error occurred. Verifier output: 0: (b7) r0 = 0\n1: exit\nR0 !read_ok")
+
+	if got, want := VerifierLog(err, 0), "0: (b7) r0 = 0\n1: exit\nR0 !read_ok"; got != want {
+		t.Errorf("VerifierLog = %q, want %q", got, want)
+	}
+	// Truncation keeps the tail, where the failing instruction is.
+	if got, want := VerifierLog(err, 12), "... !read_ok"; got != want {
+		t.Errorf("truncated VerifierLog = %q, want %q", got, want)
+	}
+	if got := VerifierLog(status.Error(codes.Aborted, "missing bytecode info"), 0); got != "" {
+		t.Errorf("VerifierLog of a non-verifier error = %q, want empty", got)
+	}
+}
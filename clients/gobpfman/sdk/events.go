@@ -0,0 +1,246 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/btf"
+	"github.com/cilium/ebpf/perf"
+	"github.com/cilium/ebpf/ringbuf"
+)
+
+// Event is a single sample read from a ring buffer or perf event array of a
+// bpfman managed program.
+type Event struct {
+	Time      time.Time `json:"time"`
+	ProgramID uint32    `json:"programId"`
+	Map       string    `json:"map"`
+	Data      []byte    `json:"data"`
+	// CPU the sample was written on, only known for perf event arrays.
+	CPU *int `json:"cpu,omitempty"`
+	// LostSamples is set instead of Data when the perf buffer of CPU
+	// overflowed and the kernel dropped samples.
+	LostSamples uint64 `json:"lostSamples,omitempty"`
+	// Decoded is Data rendered with FormatBTF when the stream was given
+	// the BTF type of the events.
+	Decoded string `json:"decoded,omitempty"`
+}
+
+// EventSink receives streamed events. Send is called from the reading
+// goroutine, so a slow sink slows down the consumer and the kernel buffer
+// may fill up. Returning an error stops the stream.
+type EventSink interface {
+	Send(ctx context.Context, ev Event) error
+}
+
+// EventSinkFunc adapts a function to an EventSink, e.g. to forward events
+// onto a channel or a gRPC stream.
+type EventSinkFunc func(ctx context.Context, ev Event) error
+
+func (f EventSinkFunc) Send(ctx context.Context, ev Event) error {
+	return f(ctx, ev)
+}
+
+// JSONEventSink writes each event as a line of JSON.
+type JSONEventSink struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewJSONEventSink returns a sink writing JSON lines to w, such as os.Stdout.
+func NewJSONEventSink(w io.Writer) *JSONEventSink {
+	return &JSONEventSink{enc: json.NewEncoder(w)}
+}
+
+func (s *JSONEventSink) Send(_ context.Context, ev Event) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.enc.Encode(ev)
+}
+
+// ErrNotExclusive is returned by the stream functions unless the caller
+// confirmed with StreamOptions.Exclusive that it is the map's only consumer.
+var ErrNotExclusive = errors.New("streaming requires exclusive use of the map")
+
+// StreamOptions configures StreamRingBuf and StreamPerfBuf.
+type StreamOptions struct {
+	// EventType is optional; when set, events are also decoded with it.
+	EventType btf.Type
+	// Exclusive confirms that nothing else, such as the program's own
+	// userspace, consumes the map while it is streamed. See StreamRingBuf
+	// and StreamPerfBuf for what happens to another consumer.
+	Exclusive bool
+	// PerCPUPages is the size, in pages, of the buffer StreamPerfBuf maps
+	// for every CPU. Zero uses DefaultPerfBufferPages.
+	PerCPUPages int
+}
+
+// StreamRingBuf reads the ringbuf map mapName of the program with the given
+// kernel ID and passes each sample to sink until ctx is cancelled or sink
+// returns an error.
+//
+// A ring buffer has a single consumer position shared by every reader, so
+// each sample goes to whichever reader gets to it first. Any other reader of
+// the map, including the application that loaded the program, silently
+// misses the samples streamed here. opts.Exclusive must be set to confirm
+// that there is no such reader, otherwise ErrNotExclusive is returned.
+func (c *Client) StreamRingBuf(ctx context.Context, progID uint32, mapName string,
+	opts StreamOptions, sink EventSink) error {
+	if !opts.Exclusive {
+		return fmt.Errorf("ring buffer %s of program %d: %w", mapName, progID, ErrNotExclusive)
+	}
+
+	m, err := c.OpenMap(ctx, progID, mapName, nil)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if m.Type() != ebpf.RingBuf {
+		return fmt.Errorf("map %s of program %d is a %s, not a ring buffer", mapName, progID, m.Type())
+	}
+
+	rd, err := ringbuf.NewReader(m)
+	if err != nil {
+		return fmt.Errorf("failed to open ring buffer %s: %w", mapName, err)
+	}
+	defer closeOnDone(ctx, rd)()
+
+	for {
+		rec, err := rd.Read()
+		if err != nil {
+			if errors.Is(err, ringbuf.ErrClosed) {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to read ring buffer %s: %w", mapName, err)
+		}
+
+		ev := newEvent(progID, mapName, rec.RawSample, opts.EventType)
+		if err := sink.Send(ctx, ev); err != nil {
+			return fmt.Errorf("event sink failed: %w", err)
+		}
+	}
+}
+
+// DefaultPerfBufferPages is the per-CPU buffer size, in pages, used by
+// StreamPerfBuf when StreamOptions.PerCPUPages is zero.
+const DefaultPerfBufferPages = 64
+
+// StreamPerfBuf is the StreamRingBuf equivalent for programs using a
+// PERF_EVENT_ARRAY map. A buffer of opts.PerCPUPages pages is mapped for
+// every CPU; overflows are reported as events with LostSamples set.
+//
+// Reading a perf event array means storing this process's perf events in
+// its slots, replacing those of any other reader, and the slots are cleared
+// again when the stream ends. Another reader of the map, including the
+// application that loaded the program, stops receiving samples and doesn't
+// get them back afterwards. The kernel doesn't let userspace see whether
+// slots are in use, so opts.Exclusive must be set to confirm that the map
+// has no other reader, otherwise ErrNotExclusive is returned.
+func (c *Client) StreamPerfBuf(ctx context.Context, progID uint32, mapName string,
+	opts StreamOptions, sink EventSink) error {
+	if !opts.Exclusive {
+		return fmt.Errorf("perf event array %s of program %d: %w", mapName, progID, ErrNotExclusive)
+	}
+
+	m, err := c.OpenMap(ctx, progID, mapName, nil)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if m.Type() != ebpf.PerfEventArray {
+		return fmt.Errorf("map %s of program %d is a %s, not a perf event array",
+			mapName, progID, m.Type())
+	}
+
+	perCPUPages := opts.PerCPUPages
+	if perCPUPages <= 0 {
+		perCPUPages = DefaultPerfBufferPages
+	}
+	rd, err := perf.NewReader(m, perCPUPages*os.Getpagesize())
+	if err != nil {
+		return fmt.Errorf("failed to open perf buffer %s: %w", mapName, err)
+	}
+	defer closeOnDone(ctx, rd)()
+
+	for {
+		rec, err := rd.Read()
+		if err != nil {
+			if errors.Is(err, perf.ErrClosed) {
+				return ctx.Err()
+			}
+			return fmt.Errorf("failed to read perf buffer %s: %w", mapName, err)
+		}
+
+		var ev Event
+		if rec.LostSamples > 0 {
+			ev = newEvent(progID, mapName, nil, nil)
+			ev.LostSamples = rec.LostSamples
+		} else {
+			ev = newEvent(progID, mapName, rec.RawSample, opts.EventType)
+		}
+		cpu := rec.CPU
+		ev.CPU = &cpu
+		if err := sink.Send(ctx, ev); err != nil {
+			return fmt.Errorf("event sink failed: %w", err)
+		}
+	}
+}
+
+func newEvent(progID uint32, mapName string, data []byte, eventType btf.Type) Event {
+	ev := Event{
+		Time:      time.Now().UTC(),
+		ProgramID: progID,
+		Map:       mapName,
+		Data:      data,
+	}
+	if eventType != nil {
+		// Events that don't match the type are still passed on raw.
+		ev.Decoded, _ = FormatBTF(eventType, data)
+	}
+	return ev
+}
+
+// closeOnDone closes rd once ctx is done to unblock a pending Read. The
+// returned function stops the watcher and must be called before returning.
+func closeOnDone(ctx context.Context, rd io.Closer) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		select {
+		case <-ctx.Done():
+		case <-stop:
+		}
+		rd.Close()
+	}()
+	return func() {
+		close(stop)
+		<-done
+	}
+}
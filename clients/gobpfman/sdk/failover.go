@@ -0,0 +1,286 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"sync"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ gobpfman.BpfmanClient = &FailoverClient{}
+
+// Endpoint is one bpfman daemon known to a FailoverClient.
+type Endpoint struct {
+	// Name identifies the endpoint, e.g. its socket path.
+	Name   string
+	Client gobpfman.BpfmanClient
+}
+
+// FailoverClient is a gobpfman.BpfmanClient over a prioritized list of bpfman
+// endpoints, e.g. an old and a new daemon running side by side during an
+// upgrade. Loads, pulls and Version go to the first endpoint that is
+// available. The endpoint that loaded each program is remembered, and Unload
+// and Get for that program are sent to it. List merges the results of every
+// available endpoint, page by page when a page size is set.
+//
+// An endpoint is skipped when a call to it fails with codes.Unavailable, and
+// any other error is returned.
+// Calls are therefore made without waiting for the connection to become
+// ready, overriding Connect's default, so that a daemon that is down fails
+// straight away instead of using up the caller's deadline.
+type FailoverClient struct {
+	endpoints []Endpoint
+
+	mu     sync.Mutex
+	owners map[uint32]int
+}
+
+// NewFailoverClient returns a FailoverClient trying endpoints in the given
+// order.
+func NewFailoverClient(endpoints ...Endpoint) (*FailoverClient, error) {
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("at least one bpfman endpoint is required")
+	}
+	return &FailoverClient{
+		endpoints: endpoints,
+		owners:    map[uint32]int{},
+	}, nil
+}
+
+// Owner returns the name of the endpoint that loaded the program with the
+// given kernel ID, if known.
+func (f *FailoverClient) Owner(id uint32) (string, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	i, ok := f.owners[id]
+	if !ok {
+		return "", false
+	}
+	return f.endpoints[i].Name, true
+}
+
+func (f *FailoverClient) setOwner(id uint32, i int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.owners[id] = i
+}
+
+func (f *FailoverClient) forget(id uint32) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.owners, id)
+}
+
+// order returns the endpoint indexes to try for a program, its owner first.
+func (f *FailoverClient) order(id uint32) []int {
+	f.mu.Lock()
+	owner, ok := f.owners[id]
+	f.mu.Unlock()
+
+	order := make([]int, 0, len(f.endpoints))
+	if ok {
+		order = append(order, owner)
+	}
+	for i := range f.endpoints {
+		if !ok || i != owner {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// failFast makes a call fail with codes.Unavailable instead of waiting for an
+// endpoint that isn't connected.
+func failFast(opts []grpc.CallOption) []grpc.CallOption {
+	return append(opts[:len(opts):len(opts)], grpc.WaitForReady(false))
+}
+
+// firstAvailable calls fn on each endpoint in priority order until one
+// doesn't fail with codes.Unavailable.
+func (f *FailoverClient) firstAvailable(fn func(i int) error) error {
+	var err error
+	for i := range f.endpoints {
+		err = fn(i)
+		if status.Code(err) != codes.Unavailable {
+			return err
+		}
+	}
+	return err
+}
+
+func (f *FailoverClient) Load(ctx context.Context, in *gobpfman.LoadRequest,
+	opts ...grpc.CallOption) (*gobpfman.LoadResponse, error) {
+	opts = failFast(opts)
+	var res *gobpfman.LoadResponse
+	err := f.firstAvailable(func(i int) error {
+		var err error
+		res, err = f.endpoints[i].Client.Load(ctx, in, opts...)
+		if err == nil {
+			f.setOwner(res.GetKernelInfo().GetId(), i)
+		}
+		return err
+	})
+	return res, err
+}
+
+func (f *FailoverClient) Unload(ctx context.Context, in *gobpfman.UnloadRequest,
+	opts ...grpc.CallOption) (*gobpfman.UnloadResponse, error) {
+	opts = failFast(opts)
+	_, known := f.Owner(in.GetId())
+
+	var err error
+	for n, i := range f.order(in.GetId()) {
+		var res *gobpfman.UnloadResponse
+		res, err = f.endpoints[i].Client.Unload(ctx, in, opts...)
+		if err == nil {
+			f.forget(in.GetId())
+			return res, nil
+		}
+		// The other daemons don't have the program, only an unreachable
+		// owner is a reason to ask them.
+		if known && n == 0 && status.Code(err) != codes.Unavailable {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (f *FailoverClient) Get(ctx context.Context, in *gobpfman.GetRequest,
+	opts ...grpc.CallOption) (*gobpfman.GetResponse, error) {
+	opts = failFast(opts)
+	var (
+		fallback *gobpfman.GetResponse
+		err      error
+	)
+	for _, i := range f.order(in.GetId()) {
+		var res *gobpfman.GetResponse
+		res, err = f.endpoints[i].Client.Get(ctx, in, opts...)
+		if status.Code(err) == codes.Unavailable {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		// Every daemon sees the kernel program, only its owner has
+		// bpfman state for it.
+		if res.GetInfo() != nil {
+			f.setOwner(in.GetId(), i)
+			return res, nil
+		}
+		if fallback == nil {
+			fallback = res
+		}
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+	return nil, err
+}
+
+func (f *FailoverClient) List(ctx context.Context, in *gobpfman.ListRequest,
+	opts ...grpc.CallOption) (*gobpfman.ListResponse, error) {
+	opts = failFast(opts)
+	merged := &gobpfman.ListResponse{}
+	seen := map[uint32]int{}
+
+	var err error
+	answered, more := false, false
+	for i, e := range f.endpoints {
+		var res *gobpfman.ListResponse
+		res, err = e.Client.List(ctx, in, opts...)
+		if status.Code(err) == codes.Unavailable {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		answered = true
+		if res.GetNextPageToken() != "" {
+			more = true
+		}
+
+		for _, r := range res.GetResults() {
+			id := r.GetKernelInfo().GetId()
+			if r.GetInfo() != nil {
+				f.setOwner(id, i)
+			}
+			if j, ok := seen[id]; ok {
+				if merged.Results[j].GetInfo() == nil && r.GetInfo() != nil {
+					merged.Results[j] = r
+				}
+				continue
+			}
+			seen[id] = len(merged.Results)
+			merged.Results = append(merged.Results, r)
+		}
+	}
+	if !answered {
+		return nil, err
+	}
+
+	// Kernel IDs are shared by all the daemons on a node, so a page of the
+	// merged list is the lowest page_size IDs of the endpoints' pages, and
+	// every endpoint continues after the last of them.
+	if pageSize := int(in.GetPageSize()); pageSize > 0 {
+		slices.SortFunc(merged.Results, func(a, b *gobpfman.ListResponse_ListResult) int {
+			return cmp.Compare(a.GetKernelInfo().GetId(), b.GetKernelInfo().GetId())
+		})
+		if len(merged.Results) > pageSize {
+			merged.Results = merged.Results[:pageSize]
+			more = true
+		}
+		if more && len(merged.Results) > 0 {
+			last := merged.Results[len(merged.Results)-1].GetKernelInfo().GetId()
+			merged.NextPageToken = strconv.FormatUint(uint64(last), 10)
+		}
+	}
+	return merged, nil
+}
+
+func (f *FailoverClient) PullBytecode(ctx context.Context, in *gobpfman.PullBytecodeRequest,
+	opts ...grpc.CallOption) (*gobpfman.PullBytecodeResponse, error) {
+	opts = failFast(opts)
+	var res *gobpfman.PullBytecodeResponse
+	err := f.firstAvailable(func(i int) error {
+		var err error
+		res, err = f.endpoints[i].Client.PullBytecode(ctx, in, opts...)
+		return err
+	})
+	return res, err
+}
+
+func (f *FailoverClient) Version(ctx context.Context, in *gobpfman.VersionRequest,
+	opts ...grpc.CallOption) (*gobpfman.VersionResponse, error) {
+	opts = failFast(opts)
+	var res *gobpfman.VersionResponse
+	err := f.firstAvailable(func(i int) error {
+		var err error
+		res, err = f.endpoints[i].Client.Version(ctx, in, opts...)
+		return err
+	})
+	return res, err
+}
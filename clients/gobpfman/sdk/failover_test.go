@@ -0,0 +1,271 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServer serves a fake.BpfmanClient as a bpfman daemon.
+type fakeServer struct {
+	gobpfman.UnimplementedBpfmanServer
+	fake *fake.BpfmanClient
+}
+
+func (s *fakeServer) Load(ctx context.Context, in *gobpfman.LoadRequest) (*gobpfman.LoadResponse, error) {
+	return s.fake.Load(ctx, in)
+}
+
+func (s *fakeServer) Unload(ctx context.Context, in *gobpfman.UnloadRequest) (*gobpfman.UnloadResponse, error) {
+	return s.fake.Unload(ctx, in)
+}
+
+func (s *fakeServer) List(ctx context.Context, in *gobpfman.ListRequest) (*gobpfman.ListResponse, error) {
+	return s.fake.List(ctx, in)
+}
+
+func (s *fakeServer) PullBytecode(ctx context.Context,
+	in *gobpfman.PullBytecodeRequest) (*gobpfman.PullBytecodeResponse, error) {
+	return s.fake.PullBytecode(ctx, in)
+}
+
+func (s *fakeServer) Get(ctx context.Context, in *gobpfman.GetRequest) (*gobpfman.GetResponse, error) {
+	return s.fake.Get(ctx, in)
+}
+
+func (s *fakeServer) Version(ctx context.Context, in *gobpfman.VersionRequest) (*gobpfman.VersionResponse, error) {
+	return s.fake.Version(ctx, in)
+}
+
+// serveFake serves f on a unix socket until the test ends and returns the
+// socket path.
+func serveFake(t *testing.T, f *fake.BpfmanClient) string {
+	t.Helper()
+
+	socketPath := filepath.Join(t.TempDir(), "bpfman.sock")
+	lis, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer()
+	gobpfman.RegisterBpfmanServer(srv, &fakeServer{fake: f})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return socketPath
+}
+
+// connectClient returns a generated client for a Connect connection to
+// socketPath.
+func connectClient(t *testing.T, socketPath string) gobpfman.BpfmanClient {
+	t.Helper()
+
+	conn, err := Connect(socketPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return gobpfman.NewBpfmanClient(conn)
+}
+
+// TestFailoverConnect checks that failover happens over Connect connections,
+// whose calls otherwise wait for a dead daemon until the deadline.
+func TestFailoverConnect(t *testing.T) {
+	dead := connectClient(t, filepath.Join(t.TempDir(), "gone.sock"))
+	live := fake.NewBpfmanClient()
+	f, err := NewFailoverClient(
+		Endpoint{Name: "old", Client: dead},
+		Endpoint{Name: "new", Client: connectClient(t, serveFake(t, live))},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	res, err := f.Load(ctx, testLoadRequest("prog"))
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	id := res.GetKernelInfo().GetId()
+	if owner, _ := f.Owner(id); owner != "new" {
+		t.Errorf("program owned by %q, want %q", owner, "new")
+	}
+
+	list, err := f.List(ctx, &gobpfman.ListRequest{})
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(list.GetResults()) != 1 {
+		t.Errorf("List returned %d programs, want 1", len(list.GetResults()))
+	}
+
+	const url = "quay.io/bpfman-bytecode/xdp_pass:latest"
+	pulled, err := PullBytecodeImage(ctx, f, BytecodeImage(url, PullIfNotPresent))
+	if err != nil {
+		t.Errorf("PullBytecode failed: %v", err)
+	} else if pulled.GetDigest() != fake.ImageDigest(url) {
+		t.Errorf("PullBytecode returned digest %q, want %q", pulled.GetDigest(), fake.ImageDigest(url))
+	}
+
+	v, err := New(f).Version(ctx)
+	if err != nil {
+		t.Fatalf("Version failed: %v", err)
+	}
+	if v.Version != fake.Version || !v.Supports(Tracing) {
+		t.Errorf("Version returned %+v", v)
+	}
+
+	if _, err := f.Unload(ctx, &gobpfman.UnloadRequest{Id: id}); err != nil {
+		t.Fatalf("Unload failed: %v", err)
+	}
+	if len(live.Programs()) != 0 {
+		t.Errorf("program %d still loaded", id)
+	}
+}
+
+func TestFailoverUnload(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	aborted := status.Error(codes.Aborted, "An error occurred. Unable to delete program")
+
+	tests := []struct {
+		name      string
+		ownerErr  error
+		wantCode  codes.Code
+		wantOther bool
+	}{
+		{name: "owner unloads", wantCode: codes.OK},
+		{name: "owner unavailable", ownerErr: unavailable, wantCode: codes.Aborted, wantOther: true},
+		{name: "owner fails", ownerErr: aborted, wantCode: codes.Aborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			owner, other := fake.NewBpfmanClient(), fake.NewBpfmanClient()
+			var otherCalled bool
+			f, err := NewFailoverClient(
+				Endpoint{Name: "owner", Client: owner},
+				Endpoint{Name: "other", Client: &recordingClient{BpfmanClient: other, unloaded: &otherCalled}},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			res, err := f.Load(context.Background(), testLoadRequest("prog"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			owner.SetError("Unload", tt.ownerErr)
+
+			_, err = f.Unload(context.Background(), &gobpfman.UnloadRequest{Id: res.GetKernelInfo().GetId()})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Unload returned %v, want code %v", err, tt.wantCode)
+			}
+			if tt.ownerErr != nil && !tt.wantOther && err != tt.ownerErr {
+				t.Errorf("Unload returned %v, want the owner's error", err)
+			}
+			if otherCalled != tt.wantOther {
+				t.Errorf("other endpoint called: %t, want %t", otherCalled, tt.wantOther)
+			}
+		})
+	}
+}
+
+// TestFailoverSkipsOnlyUnavailable checks that List and Get only move past
+// an endpoint that is unavailable and return any other error.
+func TestFailoverSkipsOnlyUnavailable(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	aborted := status.Error(codes.Aborted, "An error occurred. boom")
+
+	tests := []struct {
+		name     string
+		method   string
+		firstErr error
+		wantErr  error
+	}{
+		{name: "List unavailable", method: "List", firstErr: unavailable},
+		{name: "List fails", method: "List", firstErr: aborted, wantErr: aborted},
+		{name: "Get unavailable", method: "Get", firstErr: unavailable},
+		{name: "Get fails", method: "Get", firstErr: aborted, wantErr: aborted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, second := fake.NewBpfmanClient(), fake.NewBpfmanClient()
+			res, err := second.Load(context.Background(), testLoadRequest("prog"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			id := res.GetKernelInfo().GetId()
+			first.SetError(tt.method, tt.firstErr)
+
+			f, err := NewFailoverClient(Endpoint{Name: "first", Client: first}, Endpoint{Name: "second", Client: second})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var ids []uint32
+			switch tt.method {
+			case "List":
+				var res *gobpfman.ListResponse
+				res, err = f.List(context.Background(), &gobpfman.ListRequest{})
+				for _, r := range res.GetResults() {
+					ids = append(ids, r.GetKernelInfo().GetId())
+				}
+			case "Get":
+				var res *gobpfman.GetResponse
+				res, err = f.Get(context.Background(), &gobpfman.GetRequest{Id: id})
+				if res != nil {
+					ids = append(ids, res.GetKernelInfo().GetId())
+				}
+			}
+
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Errorf("%s returned %v, want the first endpoint's error", tt.method, err)
+				}
+				return
+			}
+			if err != nil || !slices.Equal(ids, []uint32{id}) {
+				t.Errorf("%s returned %v, %v, want program %d from the second endpoint", tt.method, ids, err, id)
+			}
+		})
+	}
+}
+
+// recordingClient records whether Unload was called.
+type recordingClient struct {
+	gobpfman.BpfmanClient
+	unloaded *bool
+}
+
+func (r *recordingClient) Unload(ctx context.Context, in *gobpfman.UnloadRequest,
+	opts ...grpc.CallOption) (*gobpfman.UnloadResponse, error) {
+	*r.unloaded = true
+	return r.BpfmanClient.Unload(ctx, in, opts...)
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+// UnloadOrphans unloads the bpfman programs whose metadata matches match but
+// for which owned returns false, e.g. programs whose owning resource was
+// deleted while the caller was down. Programs sharing another program's maps
+// are unloaded before map owners. It returns the kernel IDs that were
+// unloaded along with the combined error of those that failed.
+//
+// match must not be empty, since that would consider every program loaded
+// through bpfman, including those of other users.
+func (c *Client) UnloadOrphans(ctx context.Context, match map[string]string,
+	owned func(*gobpfman.ListResponse_ListResult) bool) ([]uint32, error) {
+	if len(match) == 0 {
+		return nil, fmt.Errorf("a metadata selector is required to unload orphaned programs")
+	}
+
+	results, err := c.ListByMetadata(ctx, nil, match)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []*gobpfman.ListResponse_ListResult
+	for _, r := range results {
+		if !owned(r) {
+			orphans = append(orphans, r)
+		}
+	}
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return orphans[i].GetInfo().MapOwnerId != nil && orphans[j].GetInfo().MapOwnerId == nil
+	})
+
+	var unloaded []uint32
+	var errs []error
+	for _, r := range orphans {
+		id := r.GetKernelInfo().GetId()
+		if err := c.Unload(ctx, id); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		unloaded = append(unloaded, id)
+	}
+	return unloaded, errors.Join(errs...)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+)
+
+// unloadLog records the kernel IDs passed to Unload, in order.
+type unloadLog struct {
+	gobpfman.BpfmanClient
+	mu  sync.Mutex
+	ids []uint32
+}
+
+func (u *unloadLog) Unload(ctx context.Context, in *gobpfman.UnloadRequest,
+	opts ...grpc.CallOption) (*gobpfman.UnloadResponse, error) {
+	u.mu.Lock()
+	u.ids = append(u.ids, in.GetId())
+	u.mu.Unlock()
+	return u.BpfmanClient.Unload(ctx, in, opts...)
+}
+
+func TestUnloadOrphans(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	ctx := context.Background()
+	load := func(name, app string, mapOwner *uint32) uint32 {
+		t.Helper()
+		req := testLoadRequest(name)
+		req.Metadata = map[string]string{"app": app}
+		req.MapOwnerId = mapOwner
+		res, err := f.Load(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.GetKernelInfo().GetId()
+	}
+
+	owner := load("owner", "mine", nil)
+	user := load("user", "mine", &owner)
+	kept := load("kept", "mine", nil)
+	other := load("other", "theirs", nil)
+
+	log := &unloadLog{BpfmanClient: f}
+	unloaded, err := New(log).UnloadOrphans(ctx, map[string]string{"app": "mine"},
+		func(r *gobpfman.ListResponse_ListResult) bool {
+			return r.GetKernelInfo().GetId() == kept
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The program using the owner's maps goes first.
+	if want := []uint32{user, owner}; !slices.Equal(log.ids, want) {
+		t.Errorf("unloaded %v, want %v", log.ids, want)
+	}
+	if !slices.Equal(unloaded, log.ids) {
+		t.Errorf("UnloadOrphans returned %v, want %v", unloaded, log.ids)
+	}
+	programs := f.Programs()
+	if _, ok := programs[kept]; !ok {
+		t.Error("owned program was unloaded")
+	}
+	if _, ok := programs[other]; !ok {
+		t.Error("program of another app was unloaded")
+	}
+}
+
+func TestUnloadOrphansRequiresMatch(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	if _, err := f.Load(context.Background(), testLoadRequest("prog")); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := New(f).UnloadOrphans(context.Background(), nil,
+		func(*gobpfman.ListResponse_ListResult) bool { return false })
+	if err == nil {
+		t.Error("UnloadOrphans accepted an empty selector")
+	}
+	if n := len(f.Programs()); n != 1 {
+		t.Errorf("%d programs loaded, want 1", n)
+	}
+}
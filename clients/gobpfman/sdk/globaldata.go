@@ -0,0 +1,152 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/btf"
+)
+
+// ErrGlobalReadOnly is returned by UpdateGlobalData for variables in .rodata.
+// Those are frozen at load time and the verifier may have folded their values
+// into the program, so changing them requires a reload.
+var ErrGlobalReadOnly = errors.New("global variable is read-only")
+
+// ErrGlobalProgramState is returned by UpdateGlobalData for variables in .bss.
+// Those are zero initialized state such as counters, which the program
+// writes itself, so an update would lose the program's concurrent writes.
+var ErrGlobalProgramState = errors.New("global variable is program state")
+
+// UpdateGlobalData rewrites global variables of the program with the given
+// kernel ID in place, without a reload. globals uses the same name to value
+// encoding as LoadOptions.GlobalData.
+//
+// Only variables in .data can be updated. bpfman doesn't create the global
+// data maps memory mappable, so the section is read, patched and written back
+// as a whole. Any write the program makes to .data in between is lost, which
+// makes this safe only for variables the program just reads, such as
+// configuration. The program may also briefly observe a mix of old and new
+// values.
+func (c *Client) UpdateGlobalData(ctx context.Context, progID uint32, globals map[string][]byte) error {
+	res, err := c.Get(ctx, progID)
+	if err != nil {
+		return err
+	}
+
+	spec, err := programBTF(progID)
+	if err != nil {
+		return err
+	}
+
+	// Section name -> variable offsets to rewrite.
+	writes := make(map[string]map[uint32][]byte)
+	for name, value := range globals {
+		section, vsi, err := findGlobal(spec, name)
+		if err != nil {
+			return err
+		}
+		if vsi.Size != uint32(len(value)) {
+			return fmt.Errorf("global %s is %d bytes, got %d", name, vsi.Size, len(value))
+		}
+		if writes[section] == nil {
+			writes[section] = make(map[uint32][]byte)
+		}
+		writes[section][vsi.Offset] = value
+	}
+
+	for _, mapID := range res.GetKernelInfo().GetMapIds() {
+		if len(writes) == 0 {
+			break
+		}
+		m, err := ebpf.NewMapFromID(ebpf.MapID(mapID))
+		if err != nil {
+			return fmt.Errorf("failed to open map %d of program %d: %w", mapID, progID, err)
+		}
+		err = updateSection(m, writes)
+		m.Close()
+		if err != nil {
+			return fmt.Errorf("failed to update globals of program %d: %w", progID, err)
+		}
+	}
+
+	for section := range writes {
+		return fmt.Errorf("program %d has no %s map", progID, section)
+	}
+	return nil
+}
+
+// findGlobal returns the section and location of the global variable name.
+func findGlobal(spec *btf.Spec, name string) (string, btf.VarSecinfo, error) {
+	for _, section := range []string{".data", ".bss", ".rodata"} {
+		var ds *btf.Datasec
+		if err := spec.TypeByName(section, &ds); err != nil {
+			continue
+		}
+		for _, vsi := range ds.Vars {
+			v, ok := vsi.Type.(*btf.Var)
+			if !ok || v.Name != name {
+				continue
+			}
+			switch section {
+			case ".bss":
+				return "", btf.VarSecinfo{}, fmt.Errorf("%s: %w", name, ErrGlobalProgramState)
+			case ".rodata":
+				return "", btf.VarSecinfo{}, fmt.Errorf("%s: %w", name, ErrGlobalReadOnly)
+			}
+			return section, vsi, nil
+		}
+	}
+	return "", btf.VarSecinfo{}, fmt.Errorf("global %s not found in program BTF", name)
+}
+
+// updateSection applies the pending writes for m's section, if m is one of
+// the global data maps, and removes them from writes.
+func updateSection(m *ebpf.Map, writes map[string]map[uint32][]byte) error {
+	info, err := m.Info()
+	if err != nil {
+		return err
+	}
+
+	for section, values := range writes {
+		// The kernel truncates map names, match on the section suffix.
+		if !strings.HasSuffix(info.Name, section) {
+			continue
+		}
+
+		var key uint32
+		var data []byte
+		if err := m.Lookup(&key, &data); err != nil {
+			return fmt.Errorf("failed to read %s: %w", section, err)
+		}
+		for offset, value := range values {
+			if int(offset)+len(value) > len(data) {
+				return fmt.Errorf("global at offset %d is outside of %s", offset, section)
+			}
+			copy(data[offset:], value)
+		}
+		if err := m.Update(&key, data, ebpf.UpdateExist); err != nil {
+			return fmt.Errorf("failed to write %s: %w", section, err)
+		}
+		delete(writes, section)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+const (
+	DefaultHealthCheckTimeout = 2 * time.Second
+	// healthProbeKey is matched by no program, so the probe List returns
+	// an empty response however many programs are loaded.
+	healthProbeKey = "bpfman.io/health-probe"
+)
+
+// HealthChecker returns a check that fails while bpfman is unreachable. It
+// matches controller-runtime's healthz.Checker, so it can be passed to
+// AddHealthzCheck and AddReadyzCheck.
+//
+// monitor, if not nil, is consulted first. If c is not nil, a trivial List is
+// also issued with the given timeout (zero uses DefaultHealthCheckTimeout),
+// which catches a bpfman that accepts connections but does not answer.
+func HealthChecker(monitor *ConnMonitor, c *Client, timeout time.Duration) func(*http.Request) error {
+	if timeout <= 0 {
+		timeout = DefaultHealthCheckTimeout
+	}
+
+	return func(req *http.Request) error {
+		if monitor != nil && !monitor.Ready() {
+			return fmt.Errorf("bpfman connection is not ready")
+		}
+		if c == nil {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), timeout)
+		defer cancel()
+
+		bpfmanOnly := true
+		_, err := c.bpfman.List(ctx, &gobpfman.ListRequest{
+			BpfmanProgramsOnly: &bpfmanOnly,
+			MatchMetadata:      map[string]string{healthProbeKey: ""},
+		})
+		if err != nil {
+			return fmt.Errorf("bpfman did not answer: %w", err)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DefaultListCacheTTL = 2 * time.Second
+	// ListCacheFetchTimeout bounds a shared List call. The call doesn't use
+	// any caller's context, since callers joining it may wait longer than
+	// the one that started it.
+	ListCacheFetchTimeout = 30 * time.Second
+)
+
+var _ gobpfman.BpfmanClient = &ListCache{}
+
+// ListCache is a gobpfman.BpfmanClient that shares List results between
+// callers for a short time, so concurrent reconciles of different program
+// types reuse one snapshot of bpfman state instead of each listing every
+// program. Any successful Load or Unload invalidates the snapshot. All other
+// calls go straight to the wrapped client.
+type ListCache struct {
+	gobpfman.BpfmanClient
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*listEntry
+}
+
+type listEntry struct {
+	done    chan struct{}
+	fetched time.Time
+	res     *gobpfman.ListResponse
+	err     error
+}
+
+// NewListCache wraps c, keeping List results for ttl (zero uses
+// DefaultListCacheTTL).
+func NewListCache(c gobpfman.BpfmanClient, ttl time.Duration) *ListCache {
+	if ttl <= 0 {
+		ttl = DefaultListCacheTTL
+	}
+	return &ListCache{
+		BpfmanClient: c,
+		ttl:          ttl,
+		entries:      map[string]*listEntry{},
+	}
+}
+
+// Invalidate drops the cached snapshot, e.g. after bpfman state was changed
+// through another client.
+func (l *ListCache) Invalidate() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = map[string]*listEntry{}
+}
+
+func (l *ListCache) List(ctx context.Context, in *gobpfman.ListRequest,
+	opts ...grpc.CallOption) (*gobpfman.ListResponse, error) {
+	keyBytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(in)
+	if err != nil {
+		return l.BpfmanClient.List(ctx, in, opts...)
+	}
+	key := string(keyBytes)
+
+	l.mu.Lock()
+	e, ok := l.entries[key]
+	if ok {
+		select {
+		case <-e.done:
+			if e.err != nil || time.Since(e.fetched) > l.ttl {
+				ok = false
+			}
+		default:
+			// Another caller is already listing, share its result.
+		}
+	}
+	if !ok {
+		e = &listEntry{done: make(chan struct{})}
+		l.entries[key] = e
+		l.mu.Unlock()
+
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), ListCacheFetchTimeout)
+		go func() {
+			defer cancel()
+			e.res, e.err = l.BpfmanClient.List(fetchCtx, in, opts...)
+			e.fetched = time.Now()
+			close(e.done)
+		}()
+	} else {
+		l.mu.Unlock()
+	}
+
+	select {
+	case <-e.done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	if e.err != nil {
+		return nil, e.err
+	}
+	return proto.Clone(e.res).(*gobpfman.ListResponse), nil
+}
+
+func (l *ListCache) Load(ctx context.Context, in *gobpfman.LoadRequest,
+	opts ...grpc.CallOption) (*gobpfman.LoadResponse, error) {
+	res, err := l.BpfmanClient.Load(ctx, in, opts...)
+	if err == nil {
+		l.Invalidate()
+	}
+	return res, err
+}
+
+func (l *ListCache) Unload(ctx context.Context, in *gobpfman.UnloadRequest,
+	opts ...grpc.CallOption) (*gobpfman.UnloadResponse, error) {
+	res, err := l.BpfmanClient.Unload(ctx, in, opts...)
+	if err == nil {
+		l.Invalidate()
+	}
+	return res, err
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+)
+
+// gatedClient counts List calls and, if held, holds each of them until
+// release is closed.
+type gatedClient struct {
+	gobpfman.BpfmanClient
+	release chan struct{}
+	lists   atomic.Int32
+}
+
+func newGatedClient(c gobpfman.BpfmanClient, held bool) *gatedClient {
+	g := &gatedClient{BpfmanClient: c, release: make(chan struct{})}
+	if !held {
+		close(g.release)
+	}
+	return g
+}
+
+func (g *gatedClient) List(ctx context.Context, in *gobpfman.ListRequest,
+	opts ...grpc.CallOption) (*gobpfman.ListResponse, error) {
+	g.lists.Add(1)
+	select {
+	case <-g.release:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return g.BpfmanClient.List(ctx, in, opts...)
+}
+
+func TestListCacheShares(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	g := newGatedClient(f, true)
+	l := NewListCache(g, time.Hour)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := l.List(context.Background(), &gobpfman.ListRequest{}); err != nil {
+				t.Errorf("List failed: %v", err)
+			}
+		}()
+	}
+	// Let every caller join the fetch before it completes.
+	time.Sleep(20 * time.Millisecond)
+	close(g.release)
+	wg.Wait()
+
+	if n := g.lists.Load(); n != 1 {
+		t.Errorf("bpfman listed %d times, want 1", n)
+	}
+}
+
+func TestListCacheInvalidation(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	g := newGatedClient(f, false)
+	l := NewListCache(g, time.Hour)
+	ctx := context.Background()
+
+	list := func() int {
+		t.Helper()
+		res, err := l.List(ctx, &gobpfman.ListRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return len(res.GetResults())
+	}
+
+	list()
+	list()
+	if n := g.lists.Load(); n != 1 {
+		t.Errorf("bpfman listed %d times, want 1", n)
+	}
+
+	res, err := l.Load(ctx, testLoadRequest("prog"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := list(); n != 1 {
+		t.Errorf("List after Load returned %d programs, want 1", n)
+	}
+
+	if _, err := l.Unload(ctx, &gobpfman.UnloadRequest{Id: res.GetKernelInfo().GetId()}); err != nil {
+		t.Fatal(err)
+	}
+	if n := list(); n != 0 {
+		t.Errorf("List after Unload returned %d programs, want 0", n)
+	}
+
+	// Different requests are cached separately.
+	bpfmanOnly := true
+	if _, err := l.List(ctx, &gobpfman.ListRequest{BpfmanProgramsOnly: &bpfmanOnly}); err != nil {
+		t.Fatal(err)
+	}
+	if n := g.lists.Load(); n != 4 {
+		t.Errorf("bpfman listed %d times, want 4", n)
+	}
+}
+
+func TestListCacheTTL(t *testing.T) {
+	g := newGatedClient(fake.NewBpfmanClient(), false)
+	l := NewListCache(g, 10*time.Millisecond)
+
+	l.List(context.Background(), &gobpfman.ListRequest{})
+	time.Sleep(20 * time.Millisecond)
+	l.List(context.Background(), &gobpfman.ListRequest{})
+
+	if n := g.lists.Load(); n != 2 {
+		t.Errorf("bpfman listed %d times, want 2", n)
+	}
+}
+
+// TestListCacheFirstCallerCancels checks that the caller that started a
+// shared List giving up doesn't fail the callers that joined it.
+func TestListCacheFirstCallerCancels(t *testing.T) {
+	g := newGatedClient(fake.NewBpfmanClient(), true)
+	l := NewListCache(g, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := l.List(ctx, &gobpfman.ListRequest{})
+		first <- err
+	}()
+	for g.lists.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	second := make(chan error)
+	go func() {
+		_, err := l.List(context.Background(), &gobpfman.ListRequest{})
+		second <- err
+	}()
+
+	cancel()
+	if err := <-first; !errors.Is(err, context.Canceled) {
+		t.Errorf("first caller got %v, want context.Canceled", err)
+	}
+	close(g.release)
+	if err := <-second; err != nil {
+		t.Errorf("second caller got %v", err)
+	}
+}
+
+func TestListCacheError(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	g := newGatedClient(f, false)
+	l := NewListCache(g, time.Hour)
+
+	f.SetError("List", errors.New("boom"))
+	if _, err := l.List(context.Background(), &gobpfman.ListRequest{}); err == nil {
+		t.Fatal("List succeeded, want the bpfman error")
+	}
+	f.SetError("List", nil)
+	if _, err := l.List(context.Background(), &gobpfman.ListRequest{}); err != nil {
+		t.Errorf("failed List was cached: %v", err)
+	}
+}
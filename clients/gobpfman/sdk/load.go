@@ -0,0 +1,169 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+// LoadOptions holds the LoadRequest fields shared by every program type.
+type LoadOptions struct {
+	// Name of the BPF function in the bytecode to load.
+	Name string
+	// Bytecode is the location of the bytecode, see FileBytecode and
+	// ImageBytecode.
+	Bytecode   *gobpfman.BytecodeLocation
+	Metadata   map[string]string
+	GlobalData map[string][]byte
+	// MapOwnerID is the kernel ID of a loaded program whose maps this
+	// program should share. Optional.
+	MapOwnerID *uint32
+	// MapInitData holds entries written into the named maps right after
+	// the load succeeds. bpfman attaches as part of the load, so the
+	// program may run briefly before they are in place. Optional.
+	MapInitData map[string][]MapEntry
+}
+
+// Load loads and attaches a program of any type. The typed Load* helpers
+// should be preferred where one exists.
+func (c *Client) Load(ctx context.Context, opts LoadOptions, programType ProgramType,
+	attach *gobpfman.AttachInfo) (*gobpfman.LoadResponse, error) {
+	if opts.Bytecode == nil {
+		return nil, fmt.Errorf("bytecode location is required to load %q", opts.Name)
+	}
+
+	req := &gobpfman.LoadRequest{
+		Bytecode:    opts.Bytecode,
+		Name:        opts.Name,
+		ProgramType: uint32(programType),
+		Attach:      attach,
+		Metadata:    opts.Metadata,
+		GlobalData:  opts.GlobalData,
+		MapOwnerId:  opts.MapOwnerID,
+	}
+
+	res, err := c.bpfman.Load(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s program %q: %w", programType, opts.Name, err)
+	}
+	if res.GetKernelInfo() == nil {
+		return nil, fmt.Errorf("kernelInfo not returned in LoadResponse for %q", opts.Name)
+	}
+
+	if len(opts.MapInitData) > 0 {
+		if err := initMaps(res.GetInfo().GetMapPinPath(), opts.MapInitData); err != nil {
+			id := res.GetKernelInfo().GetId()
+			if uerr := c.Unload(ctx, id); uerr != nil {
+				return nil, fmt.Errorf("%w (unload of program %d also failed: %v)", err, id, uerr)
+			}
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// LoadXdp loads an XDP program and attaches it to iface at the given
+// priority. proceedOn is optional and defaults to bpfman's setting.
+func (c *Client) LoadXdp(ctx context.Context, opts LoadOptions, iface string, priority int32,
+	proceedOn ...int32) (*gobpfman.LoadResponse, error) {
+	return c.Load(ctx, opts, Xdp, &gobpfman.AttachInfo{
+		Info: &gobpfman.AttachInfo_XdpAttachInfo{
+			XdpAttachInfo: &gobpfman.XDPAttachInfo{
+				Iface:     iface,
+				Priority:  priority,
+				ProceedOn: proceedOn,
+			},
+		},
+	})
+}
+
+// LoadTc loads a TC program and attaches it to iface in the given direction
+// ("ingress" or "egress") at the given priority.
+func (c *Client) LoadTc(ctx context.Context, opts LoadOptions, iface string, direction string,
+	priority int32, proceedOn ...int32) (*gobpfman.LoadResponse, error) {
+	return c.Load(ctx, opts, Tc, &gobpfman.AttachInfo{
+		Info: &gobpfman.AttachInfo_TcAttachInfo{
+			TcAttachInfo: &gobpfman.TCAttachInfo{
+				Iface:     iface,
+				Direction: direction,
+				Priority:  priority,
+				ProceedOn: proceedOn,
+			},
+		},
+	})
+}
+
+// LoadTracepoint loads a tracepoint program and attaches it to tracepoint,
+// e.g. "syscalls/sys_enter_kill".
+func (c *Client) LoadTracepoint(ctx context.Context, opts LoadOptions,
+	tracepoint string) (*gobpfman.LoadResponse, error) {
+	return c.Load(ctx, opts, Tracepoint, &gobpfman.AttachInfo{
+		Info: &gobpfman.AttachInfo_TracepointAttachInfo{
+			TracepointAttachInfo: &gobpfman.TracepointAttachInfo{
+				Tracepoint: tracepoint,
+			},
+		},
+	})
+}
+
+// LoadKprobe loads a kprobe, or a kretprobe if retprobe is set, and attaches
+// it to fnName at offset.
+func (c *Client) LoadKprobe(ctx context.Context, opts LoadOptions, fnName string, offset uint64,
+	retprobe bool) (*gobpfman.LoadResponse, error) {
+	return c.Load(ctx, opts, Kprobe, &gobpfman.AttachInfo{
+		Info: &gobpfman.AttachInfo_KprobeAttachInfo{
+			KprobeAttachInfo: &gobpfman.KprobeAttachInfo{
+				FnName:   fnName,
+				Offset:   offset,
+				Retprobe: retprobe,
+			},
+		},
+	})
+}
+
+// LoadUprobe loads a uprobe or uretprobe described by attach.
+func (c *Client) LoadUprobe(ctx context.Context, opts LoadOptions,
+	attach *gobpfman.UprobeAttachInfo) (*gobpfman.LoadResponse, error) {
+	return c.Load(ctx, opts, Kprobe, &gobpfman.AttachInfo{
+		Info: &gobpfman.AttachInfo_UprobeAttachInfo{
+			UprobeAttachInfo: attach,
+		},
+	})
+}
+
+// LoadFentry loads an fentry program and attaches it to fnName.
+func (c *Client) LoadFentry(ctx context.Context, opts LoadOptions,
+	fnName string) (*gobpfman.LoadResponse, error) {
+	return c.Load(ctx, opts, Tracing, &gobpfman.AttachInfo{
+		Info: &gobpfman.AttachInfo_FentryAttachInfo{
+			FentryAttachInfo: &gobpfman.FentryAttachInfo{FnName: fnName},
+		},
+	})
+}
+
+// LoadFexit loads an fexit program and attaches it to fnName.
+func (c *Client) LoadFexit(ctx context.Context, opts LoadOptions,
+	fnName string) (*gobpfman.LoadResponse, error) {
+	return c.Load(ctx, opts, Tracing, &gobpfman.AttachInfo{
+		Info: &gobpfman.AttachInfo_FexitAttachInfo{
+			FexitAttachInfo: &gobpfman.FexitAttachInfo{FnName: fnName},
+		},
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cilium/ebpf"
+)
+
+// ErrMapIncompatible is returned by CheckMapCompatibility when a program's
+// map definitions don't match the maps of the map owner it would share.
+var ErrMapIncompatible = errors.New("maps are incompatible with map owner")
+
+// CheckMapCompatibility compares the maps declared in spec, e.g. from
+// ebpf.LoadCollectionSpec on the bytecode file, with the maps pinned by the
+// map owner with kernel ID ownerID. Call it before a Load with MapOwnerID to
+// get a descriptive error instead of a kernel failure. Maps the owner
+// doesn't pin are not shared and are skipped.
+func (c *Client) CheckMapCompatibility(ctx context.Context, ownerID uint32,
+	spec *ebpf.CollectionSpec) error {
+	res, err := c.Get(ctx, ownerID)
+	if err != nil {
+		return err
+	}
+	pinDir := res.GetInfo().GetMapPinPath()
+	if pinDir == "" {
+		return fmt.Errorf("map owner %d has no bpfman map pin path", ownerID)
+	}
+
+	names := make([]string, 0, len(spec.Maps))
+	for name := range spec.Maps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var problems []string
+	for _, name := range names {
+		ms := spec.Maps[name]
+		m, err := ebpf.LoadPinnedMap(filepath.Join(pinDir, name), &ebpf.LoadPinOptions{ReadOnly: true})
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load map %s of owner %d: %w", name, ownerID, err)
+		}
+
+		if m.Type() != ms.Type {
+			problems = append(problems, fmt.Sprintf("%s: type %s, owner has %s", name, ms.Type, m.Type()))
+		}
+		if m.KeySize() != ms.KeySize {
+			problems = append(problems, fmt.Sprintf("%s: key size %d, owner has %d", name, ms.KeySize, m.KeySize()))
+		}
+		if m.ValueSize() != ms.ValueSize {
+			problems = append(problems, fmt.Sprintf("%s: value size %d, owner has %d", name, ms.ValueSize, m.ValueSize()))
+		}
+		// Zero max entries is filled in at load time, e.g. with the number
+		// of CPUs for perf event arrays.
+		if ms.MaxEntries != 0 && m.MaxEntries() != ms.MaxEntries {
+			problems = append(problems, fmt.Sprintf("%s: max entries %d, owner has %d",
+				name, ms.MaxEntries, m.MaxEntries()))
+		}
+		m.Close()
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("%w %d: %s", ErrMapIncompatible, ownerID, strings.Join(problems, "; "))
+	}
+	return nil
+}
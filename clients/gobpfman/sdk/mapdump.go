@@ -0,0 +1,274 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cilium/ebpf"
+	"github.com/cilium/ebpf/btf"
+)
+
+// MapEntry is a single raw key/value pair read from a map. PerCPUValues is
+// set instead of Value for per-CPU maps, with one element per possible CPU.
+type MapEntry struct {
+	Key          []byte   `json:"key"`
+	Value        []byte   `json:"value,omitempty"`
+	PerCPUValues [][]byte `json:"perCpuValues,omitempty"`
+}
+
+func isPerCPU(t ebpf.MapType) bool {
+	switch t {
+	case ebpf.PerCPUHash, ebpf.PerCPUArray, ebpf.LRUCPUHash, ebpf.PerCPUCGroupStorage:
+		return true
+	default:
+		return false
+	}
+}
+
+// DumpMap reads every entry of mapName of the program with the given kernel
+// ID. Entries are returned in kernel iteration order.
+func (c *Client) DumpMap(ctx context.Context, progID uint32, mapName string) ([]MapEntry, error) {
+	m, err := c.OpenMap(ctx, progID, mapName, &ebpf.LoadPinOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	return dumpMap(m)
+}
+
+func dumpMap(m *ebpf.Map) ([]MapEntry, error) {
+	var entries []MapEntry
+	iter := m.Iterate()
+	if isPerCPU(m.Type()) {
+		var (
+			key    []byte
+			values [][]byte
+		)
+		for iter.Next(&key, &values) {
+			entries = append(entries, MapEntry{Key: key, PerCPUValues: values})
+			key, values = nil, nil
+		}
+	} else {
+		var key, value []byte
+		for iter.Next(&key, &value) {
+			entries = append(entries, MapEntry{Key: key, Value: value})
+			key, value = nil, nil
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate map %s: %w", m, err)
+	}
+	return entries, nil
+}
+
+// MapTypes returns the BTF key and value types of mapName as declared in the
+// .maps section of the program with the given kernel ID. Maps defined without
+// BTF (legacy bpf_map_def) return an error, in which case callers should
+// fall back to hex output.
+func MapTypes(progID uint32, mapName string) (key, value btf.Type, err error) {
+	spec, err := programBTF(progID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var v *btf.Var
+	if err := spec.TypeByName(mapName, &v); err != nil {
+		return nil, nil, fmt.Errorf("no BTF map definition for %s: %w", mapName, err)
+	}
+	def, ok := btf.UnderlyingType(v.Type).(*btf.Struct)
+	if !ok {
+		return nil, nil, fmt.Errorf("BTF map definition for %s is not a struct", mapName)
+	}
+	for _, member := range def.Members {
+		ptr, ok := member.Type.(*btf.Pointer)
+		if !ok {
+			continue
+		}
+		switch member.Name {
+		case "key":
+			key = ptr.Target
+		case "value":
+			value = ptr.Target
+		}
+	}
+	if key == nil || value == nil {
+		return nil, nil, fmt.Errorf("BTF map definition for %s has no key/value types", mapName)
+	}
+	return key, value, nil
+}
+
+// programBTF loads the BTF the program with the given kernel ID was loaded
+// with.
+func programBTF(progID uint32) (*btf.Spec, error) {
+	prog, err := ebpf.NewProgramFromID(ebpf.ProgramID(progID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open program %d: %w", progID, err)
+	}
+	defer prog.Close()
+
+	info, err := prog.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get info of program %d: %w", progID, err)
+	}
+	btfID, ok := info.BTFID()
+	if !ok {
+		return nil, fmt.Errorf("program %d has no BTF", progID)
+	}
+
+	handle, err := btf.NewHandleFromID(btfID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open BTF %d: %w", btfID, err)
+	}
+	defer handle.Close()
+
+	spec, err := handle.Spec(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse BTF %d: %w", btfID, err)
+	}
+	return spec, nil
+}
+
+// FormatBTF renders data as a value of type t, e.g. "{saddr: 167772161,
+// count: 3}". Types it can't decode are printed as hex.
+func FormatBTF(t btf.Type, data []byte) (string, error) {
+	var b strings.Builder
+	if err := formatBTF(&b, t, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+func formatBTF(b *strings.Builder, t btf.Type, data []byte) error {
+	size, err := btf.Sizeof(t)
+	if err != nil {
+		return err
+	}
+	if len(data) < size {
+		return fmt.Errorf("%d bytes is too short for %s of size %d", len(data), t, size)
+	}
+	data = data[:size]
+
+	switch t := btf.UnderlyingType(t).(type) {
+	case *btf.Int:
+		n, ok := readUint(data)
+		if !ok {
+			b.WriteString("0x" + hex.EncodeToString(data))
+			break
+		}
+		switch {
+		case t.Encoding == btf.Bool:
+			fmt.Fprintf(b, "%t", n != 0)
+		case t.Encoding == btf.Char:
+			fmt.Fprintf(b, "%q", rune(n))
+		case t.Encoding == btf.Signed:
+			shift := 64 - 8*uint(size)
+			fmt.Fprintf(b, "%d", int64(n<<shift)>>shift)
+		default:
+			fmt.Fprintf(b, "%d", n)
+		}
+
+	case *btf.Enum:
+		n, ok := readUint(data)
+		if !ok {
+			b.WriteString("0x" + hex.EncodeToString(data))
+			break
+		}
+		for _, v := range t.Values {
+			if v.Value == n {
+				b.WriteString(v.Name)
+				return nil
+			}
+		}
+		fmt.Fprintf(b, "%d", n)
+
+	case *btf.Array:
+		elemSize, err := btf.Sizeof(t.Type)
+		if err != nil {
+			return err
+		}
+		if elem, ok := btf.UnderlyingType(t.Type).(*btf.Int); ok && elem.Encoding == btf.Char {
+			fmt.Fprintf(b, "%q", strings.TrimRight(string(data), "\x00"))
+			break
+		}
+		b.WriteString("[")
+		for i := 0; i < int(t.Nelems); i++ {
+			if i > 0 {
+				b.WriteString(", ")
+			}
+			if err := formatBTF(b, t.Type, data[i*elemSize:]); err != nil {
+				return err
+			}
+		}
+		b.WriteString("]")
+
+	case *btf.Struct:
+		return formatMembers(b, t.Members, data)
+
+	case *btf.Union:
+		return formatMembers(b, t.Members, data)
+
+	default:
+		b.WriteString("0x" + hex.EncodeToString(data))
+	}
+	return nil
+}
+
+func formatMembers(b *strings.Builder, members []btf.Member, data []byte) error {
+	b.WriteString("{")
+	for i, member := range members {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		if member.Name != "" {
+			b.WriteString(member.Name + ": ")
+		}
+		// Bitfields aren't byte aligned, print the bytes that hold them.
+		if member.BitfieldSize > 0 {
+			start := member.Offset / 8
+			end := (member.Offset + member.BitfieldSize + 7) / 8
+			b.WriteString("0x" + hex.EncodeToString(data[start:end]))
+			continue
+		}
+		if err := formatBTF(b, member.Type, data[member.Offset.Bytes():]); err != nil {
+			return err
+		}
+	}
+	b.WriteString("}")
+	return nil
+}
+
+// readUint decodes a native endian integer of 1, 2, 4 or 8 bytes.
+func readUint(data []byte) (uint64, bool) {
+	switch len(data) {
+	case 1:
+		return uint64(data[0]), true
+	case 2:
+		return uint64(binary.NativeEndian.Uint16(data)), true
+	case 4:
+		return uint64(binary.NativeEndian.Uint32(data)), true
+	case 8:
+		return binary.NativeEndian.Uint64(data), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/cilium/ebpf/btf"
+)
+
+func TestFormatBTF(t *testing.T) {
+	u8 := &btf.Int{Name: "__u8", Size: 1}
+	u32 := &btf.Typedef{Name: "__u32", Type: &btf.Int{Name: "unsigned int", Size: 4}}
+	s16 := &btf.Int{Name: "short", Size: 2, Encoding: btf.Signed}
+	char := &btf.Int{Name: "char", Size: 1, Encoding: btf.Char}
+	boolean := &btf.Int{Name: "_Bool", Size: 1, Encoding: btf.Bool}
+	proto := &btf.Enum{Name: "proto", Size: 4, Values: []btf.EnumValue{{Name: "TCP", Value: 6}, {Name: "UDP", Value: 17}}}
+
+	u32Bytes := func(v uint32) []byte { return binary.NativeEndian.AppendUint32(nil, v) }
+
+	tests := []struct {
+		name string
+		typ  btf.Type
+		data []byte
+		want string
+	}{
+		{name: "unsigned", typ: u32, data: u32Bytes(42), want: "42"},
+		{name: "signed", typ: s16, data: binary.NativeEndian.AppendUint16(nil, 0xfffe), want: "-2"},
+		{name: "bool", typ: boolean, data: []byte{1}, want: "true"},
+		{name: "enum", typ: proto, data: u32Bytes(17), want: "UDP"},
+		{name: "unknown enum value", typ: proto, data: u32Bytes(1), want: "1"},
+		{name: "string", typ: &btf.Array{Type: char, Nelems: 8}, data: []byte("comm\x00\x00\x00\x00"), want: `"comm"`},
+		{name: "array", typ: &btf.Array{Type: u8, Nelems: 3}, data: []byte{1, 2, 3}, want: "[1, 2, 3]"},
+		{name: "odd sized int", typ: &btf.Int{Name: "__u128", Size: 16}, data: make([]byte, 16),
+			want: "0x00000000000000000000000000000000"},
+		{
+			name: "struct",
+			typ: &btf.Struct{Name: "event", Size: 8, Members: []btf.Member{
+				{Name: "pid", Type: u32, Offset: 0},
+				{Name: "proto", Type: proto, Offset: 32},
+			}},
+			data: append(u32Bytes(7), u32Bytes(6)...),
+			want: "{pid: 7, proto: TCP}",
+		},
+		{
+			name: "bitfield",
+			typ: &btf.Struct{Name: "flags", Size: 4, Members: []btf.Member{
+				{Name: "a", Type: u32, Offset: 0, BitfieldSize: 3},
+			}},
+			data: []byte{5, 0, 0, 0},
+			want: "{a: 0x05}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FormatBTF(tt.typ, tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("FormatBTF = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatBTFShortData(t *testing.T) {
+	if _, err := FormatBTF(&btf.Int{Name: "__u32", Size: 4}, []byte{1, 2}); err == nil {
+		t.Error("FormatBTF accepted 2 bytes for a 4 byte int")
+	}
+}
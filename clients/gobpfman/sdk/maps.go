@@ -0,0 +1,142 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cilium/ebpf"
+)
+
+// MapPinPath returns the path where bpfman pinned mapName for the program
+// with the given kernel ID. Programs sharing maps through a map owner report
+// the owner's pin directory.
+func (c *Client) MapPinPath(ctx context.Context, progID uint32, mapName string) (string, error) {
+	res, err := c.Get(ctx, progID)
+	if err != nil {
+		return "", err
+	}
+
+	pinDir := res.GetInfo().GetMapPinPath()
+	if pinDir == "" {
+		return "", fmt.Errorf("program %d has no bpfman map pin path", progID)
+	}
+	return filepath.Join(pinDir, mapName), nil
+}
+
+// OpenMap opens mapName of the program with the given kernel ID from its
+// bpfman pin. opts may be nil. The caller must Close the returned map.
+func (c *Client) OpenMap(ctx context.Context, progID uint32, mapName string,
+	opts *ebpf.LoadPinOptions) (*ebpf.Map, error) {
+	pinPath, err := c.MapPinPath(ctx, progID, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	m, err := ebpf.LoadPinnedMap(pinPath, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pinned map %s: %w", pinPath, err)
+	}
+	return m, nil
+}
+
+// LookupMapEntry reads the value stored under key in mapName of the program
+// with the given kernel ID into valueOut. key and valueOut follow the
+// encoding rules of ebpf.Map.Lookup.
+func (c *Client) LookupMapEntry(ctx context.Context, progID uint32, mapName string,
+	key, valueOut any) error {
+	m, err := c.OpenMap(ctx, progID, mapName, &ebpf.LoadPinOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Lookup(key, valueOut); err != nil {
+		return fmt.Errorf("lookup in map %s of program %d failed: %w", mapName, progID, err)
+	}
+	return nil
+}
+
+// UpdateMapEntry stores value under key in mapName of the program with the
+// given kernel ID.
+func (c *Client) UpdateMapEntry(ctx context.Context, progID uint32, mapName string,
+	key, value any, flags ebpf.MapUpdateFlags) error {
+	m, err := c.OpenMap(ctx, progID, mapName, nil)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Update(key, value, flags); err != nil {
+		return fmt.Errorf("update of map %s of program %d failed: %w", mapName, progID, err)
+	}
+	return nil
+}
+
+// DeleteMapEntry removes key from mapName of the program with the given
+// kernel ID.
+func (c *Client) DeleteMapEntry(ctx context.Context, progID uint32, mapName string, key any) error {
+	m, err := c.OpenMap(ctx, progID, mapName, nil)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if err := m.Delete(key); err != nil {
+		return fmt.Errorf("delete from map %s of program %d failed: %w", mapName, progID, err)
+	}
+	return nil
+}
+
+// initMaps writes entries into the maps pinned under pinDir, keyed by map
+// name. Existing values for the same keys are overwritten.
+func initMaps(pinDir string, data map[string][]MapEntry) error {
+	if pinDir == "" {
+		return fmt.Errorf("no bpfman map pin path to initialize maps in")
+	}
+
+	for mapName, entries := range data {
+		pinPath := filepath.Join(pinDir, mapName)
+		m, err := ebpf.LoadPinnedMap(pinPath, nil)
+		if err != nil {
+			return fmt.Errorf("failed to load pinned map %s: %w", pinPath, err)
+		}
+
+		err = writeEntries(m, entries)
+		m.Close()
+		if err != nil {
+			return fmt.Errorf("failed to initialize map %s: %w", mapName, err)
+		}
+	}
+	return nil
+}
+
+// writeEntries stores entries in m, overwriting existing values.
+func writeEntries(m *ebpf.Map, entries []MapEntry) error {
+	for _, entry := range entries {
+		var value any = entry.Value
+		if entry.PerCPUValues != nil {
+			value = entry.PerCPUValues
+		}
+		if err := m.Update(entry.Key, value, ebpf.UpdateAny); err != nil {
+			return err
+		}
+	}
+	return nil
+}
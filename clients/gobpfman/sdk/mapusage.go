@@ -0,0 +1,158 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"github.com/cilium/ebpf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultScrapeTimeout bounds the List call a collector makes on each
+	// scrape when no timeout is given.
+	DefaultScrapeTimeout = 5 * time.Second
+)
+
+// MapUsageCollector is a prometheus.Collector that samples the maps pinned by
+// bpfman for every program it manages each time it is scraped. It must run
+// on the node, with access to the bpfman map pin directories.
+type MapUsageCollector struct {
+	client     *Client
+	timeout    time.Duration
+	entries    *prometheus.Desc
+	maxEntries *prometheus.Desc
+	memory     *prometheus.Desc
+}
+
+// NewMapUsageCollector creates a MapUsageCollector. timeout bounds the List
+// call made on every scrape (zero uses DefaultScrapeTimeout). Register it
+// with prometheus.Registerer.Register.
+func NewMapUsageCollector(c *Client, timeout time.Duration) *MapUsageCollector {
+	if timeout <= 0 {
+		timeout = DefaultScrapeTimeout
+	}
+	labels := []string{"program", "program_id", "map"}
+	return &MapUsageCollector{
+		client:  c,
+		timeout: timeout,
+		entries: prometheus.NewDesc("bpfman_map_entries",
+			"Number of entries currently in a bpfman managed map.", labels, nil),
+		maxEntries: prometheus.NewDesc("bpfman_map_max_entries",
+			"Maximum number of entries of a bpfman managed map.", labels, nil),
+		memory: prometheus.NewDesc("bpfman_map_used_bytes",
+			"Approximate key and value memory used by the entries of a bpfman managed map.",
+			labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (m *MapUsageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- m.entries
+	ch <- m.maxEntries
+	ch <- m.memory
+}
+
+// Collect implements prometheus.Collector.
+func (m *MapUsageCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	bpfmanOnly := true
+	results, err := listAll(ctx, m.client.bpfman, &gobpfman.ListRequest{BpfmanProgramsOnly: &bpfmanOnly})
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(m.entries, err)
+		return
+	}
+
+	for _, result := range results {
+		info := result.GetInfo()
+		// Programs sharing maps via a map owner point at the owner's pin
+		// directory, so only report each directory once, under its owner.
+		if info == nil || info.MapOwnerId != nil || info.GetMapPinPath() == "" {
+			continue
+		}
+
+		pinDir := info.GetMapPinPath()
+		dirents, err := os.ReadDir(pinDir)
+		if err != nil {
+			continue
+		}
+		progID := strconv.FormatUint(uint64(result.GetKernelInfo().GetId()), 10)
+		for _, dirent := range dirents {
+			m.collectMap(ch, filepath.Join(pinDir, dirent.Name()),
+				info.GetName(), progID, dirent.Name())
+		}
+	}
+}
+
+func (m *MapUsageCollector) collectMap(ch chan<- prometheus.Metric, pinPath string,
+	progName, progID, mapName string) {
+	bpfMap, err := ebpf.LoadPinnedMap(pinPath, &ebpf.LoadPinOptions{ReadOnly: true})
+	if err != nil {
+		return
+	}
+	defer bpfMap.Close()
+
+	count, err := countEntries(bpfMap)
+	if err != nil {
+		// Ring buffers, perf event arrays and friends can't be iterated.
+		return
+	}
+
+	labels := []string{progName, progID, mapName}
+	ch <- prometheus.MustNewConstMetric(m.entries, prometheus.GaugeValue,
+		float64(count), labels...)
+	ch <- prometheus.MustNewConstMetric(m.maxEntries, prometheus.GaugeValue,
+		float64(bpfMap.MaxEntries()), labels...)
+	ch <- prometheus.MustNewConstMetric(m.memory, prometheus.GaugeValue,
+		float64(count*int(bpfMap.KeySize()+bpfMap.ValueSize())), labels...)
+}
+
+// countEntries walks the keys of m. Arrays are fully preallocated, so they
+// always report MaxEntries.
+func countEntries(m *ebpf.Map) (int, error) {
+	switch m.Type() {
+	case ebpf.Array, ebpf.PerCPUArray:
+		return int(m.MaxEntries()), nil
+	}
+
+	var (
+		key  any
+		next []byte
+	)
+	for n := 0; ; n++ {
+		// Concurrent deletes can restart the walk, don't loop forever.
+		if n > int(m.MaxEntries()) {
+			return int(m.MaxEntries()), nil
+		}
+		if err := m.NextKey(key, &next); err != nil {
+			if errors.Is(err, ebpf.ErrKeyNotExist) {
+				return n, nil
+			}
+			return 0, err
+		}
+		key, next = next, nil
+	}
+}
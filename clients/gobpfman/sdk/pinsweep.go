@@ -0,0 +1,151 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultMapPinRoot is the bpffs directory under which bpfman pins
+	// maps, one directory per map owner.
+	DefaultMapPinRoot = "/run/bpfman/fs/maps"
+	// DefaultPinSweepMinAge keeps the sweeper away from directories of
+	// programs that are still being loaded.
+	DefaultPinSweepMinAge = time.Minute
+	// DefaultPinSweepInterval is used by Run when interval is zero.
+	DefaultPinSweepInterval = 10 * time.Minute
+)
+
+// PinSweeper removes map pin directories under Root that no program
+// managed by bpfman refers to any more. bpfman removes a map owner's
+// directory when its load fails but ignores errors doing so, and leaves
+// the directory behind when it exits or fails to record the program after
+// pinning the maps, or fails to remove it once the last program using it
+// is unloaded. Such a directory keeps its maps alive until it is removed.
+type PinSweeper struct {
+	client *Client
+	// Root is the map pin root, DefaultMapPinRoot unless changed.
+	Root string
+	// MinAge is how old a directory must be before it may be removed,
+	// DefaultPinSweepMinAge unless changed.
+	MinAge time.Duration
+
+	reclaimed prometheus.Counter
+	failures  prometheus.Counter
+}
+
+// NewPinSweeper creates a PinSweeper. Its metrics are registered with reg
+// when it is not nil.
+func NewPinSweeper(c *Client, reg prometheus.Registerer) *PinSweeper {
+	s := &PinSweeper{
+		client: c,
+		Root:   DefaultMapPinRoot,
+		MinAge: DefaultPinSweepMinAge,
+		reclaimed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "bpfman",
+			Subsystem: "pin_sweeper",
+			Name:      "reclaimed_total",
+			Help:      "Number of stale map pin directories removed.",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "bpfman",
+			Subsystem: "pin_sweeper",
+			Name:      "failures_total",
+			Help:      "Number of sweeps or removals that failed.",
+		}),
+	}
+	if reg != nil {
+		reg.MustRegister(s.reclaimed, s.failures)
+	}
+	return s
+}
+
+// Sweep removes the stale pin directories once and returns their paths.
+func (s *PinSweeper) Sweep(ctx context.Context) ([]string, error) {
+	bpfmanOnly := true
+	results, err := listAll(ctx, s.client.bpfman, &gobpfman.ListRequest{BpfmanProgramsOnly: &bpfmanOnly})
+	if err != nil {
+		s.failures.Inc()
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+
+	// Programs sharing maps report their owner's directory, which keeps it
+	// in use even after the owner itself is gone.
+	inUse := make(map[string]bool)
+	for _, r := range results {
+		if pinPath := r.GetInfo().GetMapPinPath(); pinPath != "" {
+			inUse[filepath.Clean(pinPath)] = true
+		}
+	}
+
+	dirents, err := os.ReadDir(s.Root)
+	if err != nil {
+		s.failures.Inc()
+		return nil, fmt.Errorf("failed to read %s: %w", s.Root, err)
+	}
+
+	var removed []string
+	for _, dirent := range dirents {
+		path := filepath.Join(s.Root, dirent.Name())
+		if !dirent.IsDir() || inUse[path] {
+			continue
+		}
+		info, err := dirent.Info()
+		if err != nil || time.Since(info.ModTime()) < s.MinAge {
+			continue
+		}
+
+		if err := os.RemoveAll(path); err != nil {
+			s.failures.Inc()
+			continue
+		}
+		s.reclaimed.Inc()
+		removed = append(removed, path)
+	}
+	return removed, nil
+}
+
+// Run sweeps every interval (zero uses DefaultPinSweepInterval) until ctx is
+// done. Failed sweeps are passed to onError, if not nil, and retried at the
+// next interval.
+func (s *PinSweeper) Run(ctx context.Context, interval time.Duration, onError func(error)) {
+	if interval <= 0 {
+		interval = DefaultPinSweepInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Sweep(ctx); err != nil && onError != nil && ctx.Err() == nil {
+			onError(err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+)
+
+// pinRootStub reports the fake's map pin paths under root instead of
+// fake.MapPinDir.
+type pinRootStub struct {
+	*fake.BpfmanClient
+	root string
+}
+
+func (s *pinRootStub) List(ctx context.Context, in *gobpfman.ListRequest,
+	opts ...grpc.CallOption) (*gobpfman.ListResponse, error) {
+	res, err := s.BpfmanClient.List(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range res.GetResults() {
+		if info := r.GetInfo(); info != nil {
+			info.MapPinPath = filepath.Join(s.root, strings.TrimPrefix(info.MapPinPath, fake.MapPinDir))
+		}
+	}
+	return res, nil
+}
+
+func TestPinSweeper(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	ctx := context.Background()
+	load := func(name string, mapOwner *uint32) uint32 {
+		t.Helper()
+		req := testLoadRequest(name)
+		req.MapOwnerId = mapOwner
+		res, err := f.Load(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.GetKernelInfo().GetId()
+	}
+
+	loaded := load("loaded", nil)
+	// The owner is gone but its directory is still used by user.
+	owner := load("owner", nil)
+	load("user", &owner)
+	if _, err := f.Unload(ctx, &gobpfman.UnloadRequest{Id: owner}); err != nil {
+		t.Fatal(err)
+	}
+
+	root := t.TempDir()
+	old := time.Now().Add(-2 * time.Hour)
+	mkdir := func(name string, mtime time.Time) string {
+		t.Helper()
+		path := filepath.Join(root, name)
+		if err := os.Mkdir(path, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(path, "map"), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mtime, mtime); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	inUse := []string{mkdir(fmt.Sprint(loaded), old), mkdir(fmt.Sprint(owner), old)}
+	young := mkdir("2000", time.Now())
+	stale := mkdir("2001", old)
+
+	reg := prometheus.NewRegistry()
+	s := NewPinSweeper(New(&pinRootStub{BpfmanClient: f, root: root}), reg)
+	s.Root = root
+	s.MinAge = time.Hour
+
+	removed, err := s.Sweep(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{stale}; !slices.Equal(removed, want) {
+		t.Errorf("Sweep removed %v, want %v", removed, want)
+	}
+	for _, path := range append(inUse, young) {
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("%s was removed: %v", path, err)
+		}
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("stale directory %s still exists", stale)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	i := slices.IndexFunc(families, func(mf *dto.MetricFamily) bool {
+		return mf.GetName() == "bpfman_pin_sweeper_reclaimed_total"
+	})
+	if i < 0 {
+		t.Fatal("reclaimed_total was not registered")
+	}
+	if v := families[i].GetMetric()[0].GetCounter().GetValue(); v != 1 {
+		t.Errorf("reclaimed_total is %v, want 1", v)
+	}
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"github.com/cilium/ebpf"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// RunStats are the kernel's run_cnt and run_time_ns for a program. They only
+// advance while BPF statistics (the kernel.bpf_stats_enabled sysctl) are
+// enabled.
+type RunStats struct {
+	RunCount uint64        `json:"runCount"`
+	RunTime  time.Duration `json:"runTime"`
+}
+
+const (
+	statsEnabledSysctl = "/proc/sys/kernel/bpf_stats_enabled"
+	// statsRunTime is BPF_STATS_RUN_TIME from the kernel's bpf_stats_type.
+	statsRunTime uint32 = 0
+)
+
+// EnableStats turns on BPF run time statistics for as long as the returned
+// io.Closer is open. The kernel reference counts these requests, so an agent
+// holding one never switches statistics off for other tools, and they are
+// switched off again if the agent exits.
+func EnableStats() (io.Closer, error) {
+	closer, err := ebpf.EnableStats(statsRunTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable BPF stats: %w", err)
+	}
+	return closer, nil
+}
+
+// SetStatsEnabled sets the kernel.bpf_stats_enabled sysctl, which persists
+// until it is changed again or the node reboots. EnableStats should be
+// preferred by long running processes.
+func SetStatsEnabled(enabled bool) error {
+	val := "0"
+	if enabled {
+		val = "1"
+	}
+	if err := os.WriteFile(statsEnabledSysctl, []byte(val), 0644); err != nil {
+		return fmt.Errorf("failed to set %s: %w", statsEnabledSysctl, err)
+	}
+	return nil
+}
+
+// StatsEnabled reports whether BPF run time statistics are currently being
+// collected, through the sysctl or any EnableStats holder.
+func StatsEnabled() (bool, error) {
+	val, err := os.ReadFile(statsEnabledSysctl)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", statsEnabledSysctl, err)
+	}
+	return strings.TrimSpace(string(val)) != "0", nil
+}
+
+// ProgramStats returns the run statistics of the program with the given
+// kernel ID.
+func ProgramStats(progID uint32) (*RunStats, error) {
+	prog, err := ebpf.NewProgramFromID(ebpf.ProgramID(progID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open program %d: %w", progID, err)
+	}
+	defer prog.Close()
+
+	info, err := prog.Info()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get info of program %d: %w", progID, err)
+	}
+
+	stats := &RunStats{}
+	stats.RunCount, _ = info.RunCount()
+	stats.RunTime, _ = info.Runtime()
+	return stats, nil
+}
+
+// ProgramStatsCollector is a prometheus.Collector reporting the run
+// statistics of every program managed by bpfman when it is scraped.
+type ProgramStatsCollector struct {
+	client   *Client
+	timeout  time.Duration
+	runCount *prometheus.Desc
+	runTime  *prometheus.Desc
+}
+
+// NewProgramStatsCollector creates a ProgramStatsCollector. timeout bounds
+// the List call made on every scrape (zero uses DefaultScrapeTimeout).
+func NewProgramStatsCollector(c *Client, timeout time.Duration) *ProgramStatsCollector {
+	if timeout <= 0 {
+		timeout = DefaultScrapeTimeout
+	}
+	labels := []string{"program", "program_id", "type"}
+	return &ProgramStatsCollector{
+		client:  c,
+		timeout: timeout,
+		runCount: prometheus.NewDesc("bpfman_program_run_count_total",
+			"Number of times a bpfman managed program ran while BPF stats were enabled.",
+			labels, nil),
+		runTime: prometheus.NewDesc("bpfman_program_run_time_seconds_total",
+			"Time a bpfman managed program spent running while BPF stats were enabled.",
+			labels, nil),
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (p *ProgramStatsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- p.runCount
+	ch <- p.runTime
+}
+
+// Collect implements prometheus.Collector.
+func (p *ProgramStatsCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+
+	bpfmanOnly := true
+	results, err := listAll(ctx, p.client.bpfman, &gobpfman.ListRequest{BpfmanProgramsOnly: &bpfmanOnly})
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(p.runCount, err)
+		return
+	}
+
+	for _, result := range results {
+		kernelInfo := result.GetKernelInfo()
+		stats, err := ProgramStats(kernelInfo.GetId())
+		if err != nil {
+			// Unloaded since the List call.
+			continue
+		}
+
+		labels := []string{
+			result.GetInfo().GetName(),
+			strconv.FormatUint(uint64(kernelInfo.GetId()), 10),
+			ProgramType(kernelInfo.GetProgramType()).String(),
+		}
+		ch <- prometheus.MustNewConstMetric(p.runCount, prometheus.CounterValue,
+			float64(stats.RunCount), labels...)
+		ch <- prometheus.MustNewConstMetric(p.runTime, prometheus.CounterValue,
+			stats.RunTime.Seconds(), labels...)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+// Must match the internal bpfman ImagePullPolicy mappings
+type ImagePullPolicy int32
+
+const (
+	PullAlways       ImagePullPolicy = 0
+	PullIfNotPresent ImagePullPolicy = 1
+	PullNever        ImagePullPolicy = 2
+)
+
+func (p ImagePullPolicy) String() string {
+	switch p {
+	case PullAlways:
+		return "Always"
+	case PullIfNotPresent:
+		return "IfNotPresent"
+	case PullNever:
+		return "Never"
+	default:
+		return ""
+	}
+}
+
+// BytecodeImage returns a BytecodeImage for url pulled according to policy,
+// for use with ImageBytecode and PullBytecodeImage.
+func BytecodeImage(url string, policy ImagePullPolicy) *gobpfman.BytecodeImage {
+	return &gobpfman.BytecodeImage{
+		Url:             url,
+		ImagePullPolicy: int32(policy),
+	}
+}
+
+// PullBytecodeImage asks bpfman to pull image into its local bytecode cache
+// so a later Load of the same image doesn't wait on the registry. The response
+// carries the manifest digest bpfman pulled, which is empty for an image
+// cached by a bpfman that didn't record it, and the image config labels.
+func PullBytecodeImage(ctx context.Context, c gobpfman.BpfmanClient,
+	image *gobpfman.BytecodeImage) (*gobpfman.PullBytecodeResponse, error) {
+	if image.GetUrl() == "" {
+		return nil, fmt.Errorf("image url is required to pull bytecode")
+	}
+
+	resp, err := c.PullBytecode(ctx, &gobpfman.PullBytecodeRequest{Image: image})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull bytecode image %s: %w", image.GetUrl(), err)
+	}
+	return resp, nil
+}
+
+// PullBytecodeImage is PullBytecodeImage on the Client's connection.
+func (c *Client) PullBytecodeImage(ctx context.Context,
+	image *gobpfman.BytecodeImage) (*gobpfman.PullBytecodeResponse, error) {
+	return PullBytecodeImage(ctx, c.bpfman, image)
+}
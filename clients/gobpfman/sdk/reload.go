@@ -0,0 +1,65 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+// Reload replaces the program with the given kernel ID by a new one loaded
+// from opts while keeping its maps and their contents.
+//
+// The new program is loaded as a map user of the old program's map owner
+// (the old program itself unless it already shares maps) and only then is
+// the old program unloaded. bpfman keeps a map directory pinned for as long
+// as any program uses it, so state such as connection tracking tables
+// survives. For XDP and TC both programs are attached for a short time;
+// pick a priority that keeps the new one from shadowing the old.
+//
+// opts.MapOwnerID is ignored. The map definitions of the new bytecode must
+// be compatible with the existing maps. opts.MapInitData is written into the
+// preserved maps and overwrites the entries it names.
+func (c *Client) Reload(ctx context.Context, oldID uint32, opts LoadOptions,
+	programType ProgramType, attach *gobpfman.AttachInfo) (*gobpfman.LoadResponse, error) {
+	old, err := c.Get(ctx, oldID)
+	if err != nil {
+		return nil, err
+	}
+	if old.GetInfo() == nil {
+		return nil, fmt.Errorf("program %d is not managed by bpfman", oldID)
+	}
+
+	ownerID := oldID
+	if old.GetInfo().MapOwnerId != nil {
+		ownerID = old.GetInfo().GetMapOwnerId()
+	}
+	opts.MapOwnerID = &ownerID
+
+	res, err := c.Load(ctx, opts, programType, attach)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reload program %d: %w", oldID, err)
+	}
+
+	if err := c.Unload(ctx, oldID); err != nil {
+		return res, fmt.Errorf("program %d reloaded as %d but old program was not removed: %w",
+			oldID, res.GetKernelInfo().GetId(), err)
+	}
+	return res, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestReload(t *testing.T) {
+	ctx := context.Background()
+	load := func(f *fake.BpfmanClient, name string, mapOwner *uint32) uint32 {
+		t.Helper()
+		req := testLoadRequest(name)
+		req.MapOwnerId = mapOwner
+		res, err := f.Load(ctx, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.GetKernelInfo().GetId()
+	}
+
+	tests := []struct {
+		name string
+		// setup loads the program to reload and returns its ID and the
+		// ID of the program owning its maps.
+		setup func(f *fake.BpfmanClient) (old, owner uint32)
+	}{
+		{
+			name: "map owner",
+			setup: func(f *fake.BpfmanClient) (uint32, uint32) {
+				old := load(f, "old", nil)
+				return old, old
+			},
+		},
+		{
+			name: "map user",
+			setup: func(f *fake.BpfmanClient) (uint32, uint32) {
+				owner := load(f, "owner", nil)
+				return load(f, "old", &owner), owner
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := fake.NewBpfmanClient()
+			old, owner := tt.setup(f)
+
+			other := uint32(12345)
+			opts := LoadOptions{Name: "new", Bytecode: FileBytecode("/tmp/prog.o"), MapOwnerID: &other}
+			res, err := New(f).Reload(ctx, old, opts, Tracepoint, testLoadRequest("new").GetAttach())
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			programs := f.Programs()
+			if _, ok := programs[old]; ok {
+				t.Errorf("old program %d is still loaded", old)
+			}
+			p, ok := programs[res.GetKernelInfo().GetId()]
+			if !ok {
+				t.Fatalf("new program %d is not loaded", res.GetKernelInfo().GetId())
+			}
+			if p.GetInfo().MapOwnerId == nil || p.GetInfo().GetMapOwnerId() != owner {
+				t.Errorf("new program has map owner %v, want %d", p.GetInfo().MapOwnerId, owner)
+			}
+		})
+	}
+}
+
+func TestReloadKeepsOldProgramOnLoadFailure(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	ctx := context.Background()
+	res, err := f.Load(ctx, testLoadRequest("old"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := res.GetKernelInfo().GetId()
+
+	f.SetError("Load", status.Error(codes.Aborted, "An error occurred. failed to load"))
+	opts := LoadOptions{Name: "new", Bytecode: FileBytecode("/tmp/prog.o")}
+	if _, err := New(f).Reload(ctx, old, opts, Tracepoint, &gobpfman.AttachInfo{}); err == nil {
+		t.Fatal("Reload succeeded with a failing Load")
+	}
+	if _, ok := f.Programs()[old]; !ok {
+		t.Error("old program was unloaded after the new one failed to load")
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"math/rand"
+	"path"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	DefaultRetryMaxAttempts = 5
+	DefaultRetryBaseDelay   = 100 * time.Millisecond
+	DefaultRetryMaxDelay    = 5 * time.Second
+	DefaultBreakerCooldown  = 10 * time.Second
+)
+
+// DefaultRetryableCodes are the status codes treated as transient when
+// RetryConfig.RetryableCodes is empty. codes.Aborted is deliberately left
+// out: bpfman reports every failed operation, verifier rejections included,
+// as Aborted.
+var DefaultRetryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.ResourceExhausted,
+}
+
+// DefaultRetryMethods are the bpfman operations retried when
+// RetryConfig.Methods is empty. Load and Unload are left out: a Load that
+// timed out may still have loaded the program, and retrying it loads a
+// second copy.
+var DefaultRetryMethods = []string{"List", "Get", "PullBytecode", "Version"}
+
+// RetryConfig configures RetryInterceptor. Zero values select the defaults.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts per call, including the
+	// first one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry. Each later retry
+	// doubles it, up to MaxDelay, and a random jitter of up to the full delay
+	// is applied.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// RetryableCodes lists the status codes that are retried.
+	RetryableCodes []codes.Code
+	// Methods lists the bpfman operations that are retried, by name, e.g.
+	// "List". Add "Load" and "Unload" only if the caller can cope with a
+	// call being applied twice.
+	Methods []string
+	// BreakerThreshold is the number of consecutive calls failing with
+	// codes.Unavailable after which the circuit breaker opens and calls fail
+	// fast.
+	// Zero disables the circuit breaker.
+	BreakerThreshold int
+	// BreakerCooldown is how long the breaker stays open before a single
+	// trial call is let through.
+	BreakerCooldown time.Duration
+}
+
+func (r RetryConfig) withDefaults() RetryConfig {
+	if r.MaxAttempts <= 0 {
+		r.MaxAttempts = DefaultRetryMaxAttempts
+	}
+	if r.BaseDelay <= 0 {
+		r.BaseDelay = DefaultRetryBaseDelay
+	}
+	if r.MaxDelay <= 0 {
+		r.MaxDelay = DefaultRetryMaxDelay
+	}
+	if len(r.RetryableCodes) == 0 {
+		r.RetryableCodes = DefaultRetryableCodes
+	}
+	if len(r.Methods) == 0 {
+		r.Methods = DefaultRetryMethods
+	}
+	if r.BreakerCooldown <= 0 {
+		r.BreakerCooldown = DefaultBreakerCooldown
+	}
+	return r
+}
+
+func (r RetryConfig) retryable(err error) bool {
+	code := status.Code(err)
+	for _, c := range r.RetryableCodes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (r RetryConfig) retryMethod(method string) bool {
+	name := path.Base(method)
+	for _, m := range r.Methods {
+		if m == name {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the jittered delay before the given retry (1-based).
+func (r RetryConfig) backoff(retry int) time.Duration {
+	delay := r.BaseDelay
+	for i := 1; i < retry && delay < r.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+// RetryInterceptor returns a unary client interceptor that retries transient
+// errors with jittered exponential backoff and, if cfg.BreakerThreshold is
+// set, stops calling bpfman altogether while it keeps failing. Calls made
+// through it don't wait for the connection to become ready, overriding
+// Connect's default, so that an unreachable bpfman fails with
+// codes.Unavailable instead of running into the caller's deadline.
+func RetryInterceptor(cfg RetryConfig) grpc.UnaryClientInterceptor {
+	cfg = cfg.withDefaults()
+	breaker := &circuitBreaker{
+		threshold: cfg.BreakerThreshold,
+		cooldown:  cfg.BreakerCooldown,
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !breaker.allow() {
+			return status.Errorf(codes.Unavailable, "%s: circuit breaker open, bpfman is unavailable", method)
+		}
+
+		opts = append(opts, grpc.WaitForReady(false))
+		retry := cfg.retryMethod(method)
+
+		var err error
+		for attempt := 1; ; attempt++ {
+			err = invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || !retry || !cfg.retryable(err) || attempt >= cfg.MaxAttempts {
+				break
+			}
+
+			timer := time.NewTimer(cfg.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				breaker.record(err)
+				return err
+			case <-timer.C:
+			}
+		}
+
+		breaker.record(err)
+		return err
+	}
+}
+
+// circuitBreaker counts consecutive codes.Unavailable failures. Once threshold is
+// reached it rejects calls until cooldown has passed, then lets one trial call
+// through to decide whether to close again.
+type circuitBreaker struct {
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+func (b *circuitBreaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if b.trial || time.Since(b.openedAt) < b.cooldown {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+func (b *circuitBreaker) record(err error) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.trial = false
+	if status.Code(err) != codes.Unavailable {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openedAt = time.Now()
+	}
+}
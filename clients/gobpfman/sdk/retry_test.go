@@ -0,0 +1,153 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// scriptedInvoker fails the calls made through it with errs in turn, then
+// succeeds, and counts the calls.
+type scriptedInvoker struct {
+	errs  []error
+	calls int
+}
+
+func (s *scriptedInvoker) invoke(ctx context.Context, method string, req, reply any,
+	cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	s.calls++
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func TestRetryInterceptor(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	aborted := status.Error(codes.Aborted, "An error occurred. Verifier output: ...")
+
+	tests := []struct {
+		name      string
+		method    string
+		errs      []error
+		wantCalls int
+		wantCode  codes.Code
+	}{
+		{name: "list recovers", method: "/bpfman.v1.Bpfman/List",
+			errs: []error{unavailable, unavailable}, wantCalls: 3, wantCode: codes.OK},
+		{name: "list gives up", method: "/bpfman.v1.Bpfman/List",
+			errs: []error{unavailable, unavailable, unavailable, unavailable}, wantCalls: 3, wantCode: codes.Unavailable},
+		{name: "get aborted", method: "/bpfman.v1.Bpfman/Get",
+			errs: []error{aborted}, wantCalls: 1, wantCode: codes.Aborted},
+		{name: "load not retried", method: "/bpfman.v1.Bpfman/Load",
+			errs: []error{unavailable}, wantCalls: 1, wantCode: codes.Unavailable},
+		{name: "unload not retried", method: "/bpfman.v1.Bpfman/Unload",
+			errs: []error{unavailable}, wantCalls: 1, wantCode: codes.Unavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := RetryInterceptor(RetryConfig{
+				MaxAttempts: 3,
+				BaseDelay:   time.Millisecond,
+				MaxDelay:    time.Millisecond,
+			})
+			inv := &scriptedInvoker{errs: tt.errs}
+
+			err := interceptor(context.Background(), tt.method, nil, nil, nil, inv.invoke)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("got %v, want code %v", err, tt.wantCode)
+			}
+			if inv.calls != tt.wantCalls {
+				t.Errorf("made %d calls, want %d", inv.calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRetryInterceptorMethods(t *testing.T) {
+	interceptor := RetryInterceptor(RetryConfig{
+		MaxAttempts: 2,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		Methods:     []string{"Load"},
+	})
+	inv := &scriptedInvoker{errs: []error{status.Error(codes.Unavailable, "connection refused")}}
+
+	if err := interceptor(context.Background(), "/bpfman.v1.Bpfman/Load", nil, nil, nil, inv.invoke); err != nil {
+		t.Errorf("Load failed: %v", err)
+	}
+	if inv.calls != 2 {
+		t.Errorf("made %d calls, want 2", inv.calls)
+	}
+}
+
+func TestRetryInterceptorBreaker(t *testing.T) {
+	const cooldown = 50 * time.Millisecond
+	interceptor := RetryInterceptor(RetryConfig{
+		MaxAttempts:      1,
+		BreakerThreshold: 2,
+		BreakerCooldown:  cooldown,
+	})
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	inv := &scriptedInvoker{errs: []error{unavailable, unavailable}}
+	call := func() error {
+		return interceptor(context.Background(), "/bpfman.v1.Bpfman/Load", nil, nil, nil, inv.invoke)
+	}
+
+	call()
+	call()
+	if err := call(); status.Code(err) != codes.Unavailable || inv.calls != 2 {
+		t.Fatalf("open breaker returned %v after %d calls, want Unavailable without a call", err, inv.calls)
+	}
+
+	time.Sleep(cooldown)
+	if err := call(); err != nil || inv.calls != 3 {
+		t.Fatalf("trial call returned %v after %d calls, want success", err, inv.calls)
+	}
+	if err := call(); err != nil || inv.calls != 4 {
+		t.Errorf("closed breaker returned %v after %d calls, want success", err, inv.calls)
+	}
+}
+
+func TestRetryInterceptorFailsFast(t *testing.T) {
+	interceptor := RetryInterceptor(RetryConfig{})
+	var waitForReady *bool
+	invoker := func(ctx context.Context, method string, req, reply any,
+		cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		for _, o := range opts {
+			if w, ok := o.(grpc.FailFastCallOption); ok {
+				wait := !w.FailFast
+				waitForReady = &wait
+			}
+		}
+		return nil
+	}
+
+	interceptor(context.Background(), "/bpfman.v1.Bpfman/List", nil, nil, nil, invoker)
+	if waitForReady == nil || *waitForReady {
+		t.Error("call was not made with WaitForReady(false)")
+	}
+}
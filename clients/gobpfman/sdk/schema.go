@@ -0,0 +1,128 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"fmt"
+
+	"github.com/cilium/ebpf/btf"
+)
+
+// FieldSchema describes one member of a struct or union key/value type.
+type FieldSchema struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Offset uint32 `json:"offset"`
+	Size   int    `json:"size"`
+}
+
+// TypeSchema is a flattened, serializable description of a BTF type. Fields
+// is only set for structs and unions.
+type TypeSchema struct {
+	Type   string        `json:"type"`
+	Size   int           `json:"size"`
+	Fields []FieldSchema `json:"fields,omitempty"`
+}
+
+// MapSchema describes the key and value layout of a map.
+type MapSchema struct {
+	Key   TypeSchema `json:"key"`
+	Value TypeSchema `json:"value"`
+}
+
+// GetMapSchema derives the schema of mapName from the BTF of the program with
+// the given kernel ID. It is meant to be published next to the map so that
+// consumers can decode entries without the original object file.
+func GetMapSchema(progID uint32, mapName string) (*MapSchema, error) {
+	key, value, err := MapTypes(progID, mapName)
+	if err != nil {
+		return nil, err
+	}
+
+	keySchema, err := typeSchema(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe key of map %s: %w", mapName, err)
+	}
+	valueSchema, err := typeSchema(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe value of map %s: %w", mapName, err)
+	}
+	return &MapSchema{Key: *keySchema, Value: *valueSchema}, nil
+}
+
+func typeSchema(t btf.Type) (*TypeSchema, error) {
+	size, err := btf.Sizeof(t)
+	if err != nil {
+		return nil, err
+	}
+	schema := &TypeSchema{Type: typeString(t), Size: size}
+
+	var members []btf.Member
+	switch u := btf.UnderlyingType(t).(type) {
+	case *btf.Struct:
+		members = u.Members
+	case *btf.Union:
+		members = u.Members
+	}
+	for _, member := range members {
+		memberSize, err := btf.Sizeof(member.Type)
+		if err != nil {
+			return nil, err
+		}
+		schema.Fields = append(schema.Fields, FieldSchema{
+			Name:   member.Name,
+			Type:   typeString(member.Type),
+			Offset: member.Offset.Bytes(),
+			Size:   memberSize,
+		})
+	}
+	return schema, nil
+}
+
+// typeString renders t roughly as it would be spelled in C, keeping typedef
+// names such as __u32 since they carry the intent of the field.
+func typeString(t btf.Type) string {
+	switch t := t.(type) {
+	case *btf.Typedef:
+		return t.Name
+	case *btf.Const:
+		return typeString(t.Type)
+	case *btf.Volatile:
+		return typeString(t.Type)
+	case *btf.Int:
+		return t.Name
+	case *btf.Pointer:
+		return typeString(t.Target) + " *"
+	case *btf.Array:
+		return fmt.Sprintf("%s[%d]", typeString(t.Type), t.Nelems)
+	case *btf.Struct:
+		return "struct " + anonymous(t.Name)
+	case *btf.Union:
+		return "union " + anonymous(t.Name)
+	case *btf.Enum:
+		return "enum " + anonymous(t.Name)
+	default:
+		return fmt.Sprintf("%T", t)
+	}
+}
+
+func anonymous(name string) string {
+	if name == "" {
+		return "<anon>"
+	}
+	return name
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sdk provides typed helpers on top of the generated gobpfman client
+// so Go consumers don't have to hand-assemble LoadRequest protos.
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// Must match the internal bpfman-api mappings
+type ProgramType uint32
+
+const (
+	Kprobe     ProgramType = 2
+	Tc         ProgramType = 3
+	Tracepoint ProgramType = 5
+	Xdp        ProgramType = 6
+	Tracing    ProgramType = 26
+)
+
+func (p ProgramType) Uint32() *uint32 {
+	progTypeInt := uint32(p)
+	return &progTypeInt
+}
+
+func (p ProgramType) String() string {
+	switch p {
+	case Kprobe:
+		return "kprobe"
+	case Tc:
+		return "tc"
+	case Xdp:
+		return "xdp"
+	case Tracepoint:
+		return "tracepoint"
+	case Tracing:
+		return "tracing"
+	default:
+		return ""
+	}
+}
+
+const (
+	DefaultWaitInterval = 500 * time.Millisecond
+	DefaultListPageSize = 500
+)
+
+// Client wraps a gobpfman.BpfmanClient with typed helpers.
+type Client struct {
+	bpfman gobpfman.BpfmanClient
+}
+
+// New returns a Client backed by the given gobpfman.BpfmanClient.
+func New(c gobpfman.BpfmanClient) *Client {
+	return &Client{bpfman: c}
+}
+
+// NewFromConn returns a Client that issues its calls on conn.
+func NewFromConn(conn grpc.ClientConnInterface) *Client {
+	return New(gobpfman.NewBpfmanClient(conn))
+}
+
+// Bpfman returns the underlying generated client for calls the SDK doesn't
+// wrap.
+func (c *Client) Bpfman() gobpfman.BpfmanClient {
+	return c.bpfman
+}
+
+// FileBytecode returns a BytecodeLocation for a local bytecode file.
+func FileBytecode(path string) *gobpfman.BytecodeLocation {
+	return &gobpfman.BytecodeLocation{
+		Location: &gobpfman.BytecodeLocation_File{File: path},
+	}
+}
+
+// ImageBytecode returns a BytecodeLocation for bytecode packaged in an OCI
+// container image, pulled according to policy.
+func ImageBytecode(url string, policy ImagePullPolicy) *gobpfman.BytecodeLocation {
+	return &gobpfman.BytecodeLocation{
+		Location: &gobpfman.BytecodeLocation_Image{
+			Image: BytecodeImage(url, policy),
+		},
+	}
+}
+
+// Unload unloads the bpfman program with the given kernel ID.
+func (c *Client) Unload(ctx context.Context, id uint32) error {
+	_, err := c.bpfman.Unload(ctx, &gobpfman.UnloadRequest{Id: id})
+	if err != nil {
+		return fmt.Errorf("failed to unload program %d: %w", id, err)
+	}
+	return nil
+}
+
+// Get returns the bpfman and kernel state for the program with the given
+// kernel ID.
+func (c *Client) Get(ctx context.Context, id uint32) (*gobpfman.GetResponse, error) {
+	res, err := c.bpfman.Get(ctx, &gobpfman.GetRequest{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get program %d: %w", id, err)
+	}
+	return res, nil
+}
+
+// WaitForLoaded polls bpfman every interval until the program with the given
+// kernel ID is reported as loaded by bpfman, or ctx is done. An interval of
+// zero uses DefaultWaitInterval.
+func (c *Client) WaitForLoaded(ctx context.Context, id uint32, interval time.Duration) (*gobpfman.GetResponse, error) {
+	if interval <= 0 {
+		interval = DefaultWaitInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		res, err := c.bpfman.Get(ctx, &gobpfman.GetRequest{Id: id})
+		if err == nil && res.GetInfo() != nil {
+			return res, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return nil, fmt.Errorf("program %d not loaded: %w", id, err)
+			}
+			return nil, fmt.Errorf("program %d not loaded: %w", id, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// ListByMetadata returns the bpfman programs whose metadata contains every
+// key/value pair in match. A nil programType matches all program types.
+func (c *Client) ListByMetadata(ctx context.Context, programType *ProgramType,
+	match map[string]string) ([]*gobpfman.ListResponse_ListResult, error) {
+	bpfmanOnly := true
+	req := &gobpfman.ListRequest{
+		BpfmanProgramsOnly: &bpfmanOnly,
+		MatchMetadata:      match,
+	}
+	if programType != nil {
+		req.ProgramType = programType.Uint32()
+	}
+
+	results, err := listAll(ctx, c.bpfman, req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list programs: %w", err)
+	}
+	return results, nil
+}
+
+// ListPages issues req one page of pageSize programs at a time (zero uses
+// DefaultListPageSize) and calls fn with the results of each page, so that
+// nodes with many programs don't need a single large List response. Paging
+// stops at the first error returned by fn. A bpfman that doesn't support
+// paging returns every program in the first page.
+func ListPages(ctx context.Context, c gobpfman.BpfmanClient, req *gobpfman.ListRequest, pageSize uint32,
+	fn func([]*gobpfman.ListResponse_ListResult) error) error {
+	if pageSize == 0 {
+		pageSize = DefaultListPageSize
+	}
+	req = proto.Clone(req).(*gobpfman.ListRequest)
+	req.PageSize = pageSize
+	req.PageToken = ""
+
+	for {
+		res, err := c.List(ctx, req)
+		if err != nil {
+			return err
+		}
+		if err := fn(res.GetResults()); err != nil {
+			return err
+		}
+		if res.GetNextPageToken() == "" {
+			return nil
+		}
+		req.PageToken = res.GetNextPageToken()
+	}
+}
+
+// listAll returns the results of every page of req.
+func listAll(ctx context.Context, c gobpfman.BpfmanClient,
+	req *gobpfman.ListRequest) ([]*gobpfman.ListResponse_ListResult, error) {
+	var results []*gobpfman.ListResponse_ListResult
+	err := ListPages(ctx, c, req, 0, func(page []*gobpfman.ListResponse_ListResult) error {
+		results = append(results, page...)
+		return nil
+	})
+	return results, err
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+func TestListPages(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	for _, name := range []string{"a", "b", "c", "d", "e"} {
+		if _, err := f.Load(context.Background(), testLoadRequest(name)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, pageSize := range []uint32{1, 2, 5, 6} {
+		var pages, ids []uint32
+		err := ListPages(context.Background(), f, &gobpfman.ListRequest{}, pageSize,
+			func(page []*gobpfman.ListResponse_ListResult) error {
+				pages = append(pages, uint32(len(page)))
+				for _, r := range page {
+					ids = append(ids, r.GetKernelInfo().GetId())
+				}
+				return nil
+			})
+		if err != nil {
+			t.Fatalf("page size %d: %v", pageSize, err)
+		}
+		if len(ids) != 5 {
+			t.Fatalf("page size %d: listed %v, want 5 programs", pageSize, ids)
+		}
+		for i, id := range ids {
+			if id != fake.FirstProgramID+uint32(i) {
+				t.Errorf("page size %d: listed %v, want kernel ID order", pageSize, ids)
+				break
+			}
+		}
+		for _, n := range pages {
+			if n > pageSize {
+				t.Errorf("page size %d: got pages of %v programs", pageSize, pages)
+				break
+			}
+		}
+	}
+}
+
+func TestListByMetadataPages(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	for i := 0; i < DefaultListPageSize+1; i++ {
+		req := testLoadRequest("prog")
+		req.Metadata = map[string]string{"app": "test"}
+		if _, err := f.Load(context.Background(), req); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	results, err := New(f).ListByMetadata(context.Background(), nil, map[string]string{"app": "test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != DefaultListPageSize+1 {
+		t.Errorf("ListByMetadata returned %d programs, want %d", len(results), DefaultListPageSize+1)
+	}
+}
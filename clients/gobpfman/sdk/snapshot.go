@@ -0,0 +1,89 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cilium/ebpf"
+)
+
+// MapSnapshot is a point in time copy of a map's contents. It is JSON
+// serializable so it can be stored in a ConfigMap, on a PVC or in a file.
+type MapSnapshot struct {
+	Map        string       `json:"map"`
+	Type       ebpf.MapType `json:"type"`
+	KeySize    uint32       `json:"keySize"`
+	ValueSize  uint32       `json:"valueSize"`
+	MaxEntries uint32       `json:"maxEntries"`
+	TakenAt    time.Time    `json:"takenAt"`
+	Entries    []MapEntry   `json:"entries"`
+}
+
+// SnapshotMap copies every entry of mapName of the program with the given
+// kernel ID. Entries written while the snapshot is taken may or may not be
+// included.
+func (c *Client) SnapshotMap(ctx context.Context, progID uint32, mapName string) (*MapSnapshot, error) {
+	m, err := c.OpenMap(ctx, progID, mapName, &ebpf.LoadPinOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	entries, err := dumpMap(m)
+	if err != nil {
+		return nil, err
+	}
+	return &MapSnapshot{
+		Map:        mapName,
+		Type:       m.Type(),
+		KeySize:    m.KeySize(),
+		ValueSize:  m.ValueSize(),
+		MaxEntries: m.MaxEntries(),
+		TakenAt:    time.Now().UTC(),
+		Entries:    entries,
+	}, nil
+}
+
+// RestoreMap writes snap into mapName of the program with the given kernel
+// ID, e.g. after a reload or on a replacement node. The map must have the
+// same type, key size and value size as the one the snapshot was taken
+// from. Entries not in the snapshot are left alone.
+func (c *Client) RestoreMap(ctx context.Context, progID uint32, mapName string, snap *MapSnapshot) error {
+	m, err := c.OpenMap(ctx, progID, mapName, nil)
+	if err != nil {
+		return err
+	}
+	defer m.Close()
+
+	if m.Type() != snap.Type || m.KeySize() != snap.KeySize || m.ValueSize() != snap.ValueSize {
+		return fmt.Errorf("snapshot of %s (%s, key %d, value %d) doesn't fit map %s of program %d (%s, key %d, value %d)",
+			snap.Map, snap.Type, snap.KeySize, snap.ValueSize,
+			mapName, progID, m.Type(), m.KeySize(), m.ValueSize())
+	}
+	if uint32(len(snap.Entries)) > m.MaxEntries() {
+		return fmt.Errorf("snapshot of %s has %d entries, map %s of program %d holds at most %d",
+			snap.Map, len(snap.Entries), mapName, progID, m.MaxEntries())
+	}
+
+	if err := writeEntries(m, snap.Entries); err != nil {
+		return fmt.Errorf("failed to restore map %s of program %d: %w", mapName, progID, err)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Timeouts sets the deadline applied to each bpfman operation. A zero value
+// falls back to Default, and a zero Default leaves the caller's context
+// untouched.
+type Timeouts struct {
+	Load         time.Duration
+	Unload       time.Duration
+	List         time.Duration
+	Get          time.Duration
+	PullBytecode time.Duration
+	Version      time.Duration
+	Default      time.Duration
+}
+
+func (t Timeouts) forMethod(method string) time.Duration {
+	var d time.Duration
+	switch path.Base(method) {
+	case "Load":
+		d = t.Load
+	case "Unload":
+		d = t.Unload
+	case "List":
+		d = t.List
+	case "Get":
+		d = t.Get
+	case "PullBytecode":
+		d = t.PullBytecode
+	case "Version":
+		d = t.Version
+	}
+	if d == 0 {
+		d = t.Default
+	}
+	return d
+}
+
+// TimeoutInterceptor returns a unary client interceptor that bounds every
+// call by its configured timeout, so a hung bpfman call can't stall the
+// caller indefinitely. A deadline already on the context is kept if it is
+// earlier.
+func TimeoutInterceptor(t Timeouts) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if d := t.forMethod(method); d > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, d)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestTimeoutInterceptor(t *testing.T) {
+	interceptor := TimeoutInterceptor(Timeouts{Load: time.Minute, Version: 5 * time.Second, Default: time.Second})
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		method string
+		want   time.Duration
+	}{
+		{name: "per method", ctx: context.Background(), method: "/bpfman.v1.Bpfman/Load", want: time.Minute},
+		{name: "version", ctx: context.Background(), method: "/bpfman.v1.Bpfman/Version", want: 5 * time.Second},
+		{name: "default", ctx: context.Background(), method: "/bpfman.v1.Bpfman/List", want: time.Second},
+		{name: "earlier caller deadline", ctx: withTimeout(t, 10*time.Millisecond),
+			method: "/bpfman.v1.Bpfman/Load", want: 10 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			invoker := func(ctx context.Context, method string, req, reply any,
+				cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				deadline, ok := ctx.Deadline()
+				if !ok {
+					t.Fatal("call has no deadline")
+				}
+				remaining = time.Until(deadline)
+				return nil
+			}
+
+			interceptor(tt.ctx, tt.method, nil, nil, nil, invoker)
+			if remaining > tt.want || remaining < tt.want/2 {
+				t.Errorf("call had %v left, want about %v", remaining, tt.want)
+			}
+		})
+	}
+}
+
+func withTimeout(t *testing.T, d time.Duration) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), d)
+	t.Cleanup(cancel)
+	return ctx
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+// DaemonVersion is what bpfman reports about itself.
+type DaemonVersion struct {
+	// Version is the bpfman release, e.g. "0.5.0".
+	Version string
+	// ProgramTypes are the program types bpfman can load.
+	ProgramTypes []ProgramType
+}
+
+// Supports reports whether bpfman can load programs of type t.
+func (v *DaemonVersion) Supports(t ProgramType) bool {
+	return slices.Contains(v.ProgramTypes, t)
+}
+
+// Version asks bpfman for its version and the program types it can load.
+// bpfman releases that predate the Version call fail with
+// codes.Unimplemented.
+func (c *Client) Version(ctx context.Context) (*DaemonVersion, error) {
+	res, err := c.bpfman.Version(ctx, &gobpfman.VersionRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the bpfman version: %w", err)
+	}
+
+	v := &DaemonVersion{Version: res.GetVersion()}
+	for _, t := range res.GetProgramTypes() {
+		v.ProgramTypes = append(v.ProgramTypes, ProgramType(t))
+	}
+	return v, nil
+}
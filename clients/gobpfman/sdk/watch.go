@@ -0,0 +1,138 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"time"
+
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+const (
+	DefaultWatchInterval = 5 * time.Second
+)
+
+type ProgramEventType int
+
+const (
+	ProgramAdded ProgramEventType = iota
+	ProgramRemoved
+)
+
+func (t ProgramEventType) String() string {
+	switch t {
+	case ProgramAdded:
+		return "added"
+	case ProgramRemoved:
+		return "removed"
+	default:
+		return ""
+	}
+}
+
+// ProgramEvent reports a program that appeared in or disappeared from the
+// bpfman List results between two polls.
+type ProgramEvent struct {
+	Type ProgramEventType
+	// Program is the List result the program was last seen with.
+	Program *gobpfman.ListResponse_ListResult
+}
+
+// WatchPrograms emulates a watch on bpfman's programs by listing them every
+// interval (zero uses DefaultWatchInterval) and diffing the kernel IDs, since
+// bpfman has no streaming RPC. req filters the List calls and may be nil.
+//
+// The programs present at the first successful List are reported as
+// ProgramAdded. Failed List calls are passed to onError, if not nil, and
+// retried at the next interval. The returned channel is closed when ctx is
+// done.
+func (c *Client) WatchPrograms(ctx context.Context, req *gobpfman.ListRequest, interval time.Duration,
+	onError func(error)) <-chan ProgramEvent {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	if req == nil {
+		req = &gobpfman.ListRequest{}
+	}
+
+	events := make(chan ProgramEvent)
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		known := map[uint32]*gobpfman.ListResponse_ListResult{}
+		for {
+			results, err := listAll(ctx, c.bpfman, req)
+			if err != nil {
+				if onError != nil && ctx.Err() == nil {
+					onError(err)
+				}
+			} else if !c.diffPrograms(ctx, known, results, events) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events
+}
+
+// diffPrograms sends an event for every program added to or removed from
+// known and updates known to match results. It returns false if ctx was done
+// before all events were delivered.
+func (c *Client) diffPrograms(ctx context.Context, known map[uint32]*gobpfman.ListResponse_ListResult,
+	results []*gobpfman.ListResponse_ListResult, events chan<- ProgramEvent) bool {
+	current := make(map[uint32]*gobpfman.ListResponse_ListResult, len(results))
+	for _, r := range results {
+		current[r.GetKernelInfo().GetId()] = r
+	}
+
+	send := func(ev ProgramEvent) bool {
+		select {
+		case events <- ev:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	for id, r := range known {
+		if _, ok := current[id]; !ok {
+			delete(known, id)
+			if !send(ProgramEvent{Type: ProgramRemoved, Program: r}) {
+				return false
+			}
+		}
+	}
+	for id, r := range current {
+		if _, ok := known[id]; !ok {
+			known[id] = r
+			if !send(ProgramEvent{Type: ProgramAdded, Program: r}) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sdk
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/bpfman/bpfman/clients/gobpfman/fake"
+	gobpfman "github.com/bpfman/bpfman/clients/gobpfman/v1"
+)
+
+func nextEvent(t *testing.T, events <-chan ProgramEvent) ProgramEvent {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatal("events channel closed")
+		}
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event")
+	}
+	return ProgramEvent{}
+}
+
+func TestWatchPrograms(t *testing.T) {
+	f := fake.NewBpfmanClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	loaded := map[uint32]bool{}
+	for _, name := range []string{"a", "b"} {
+		res, err := f.Load(ctx, testLoadRequest(name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		loaded[res.GetKernelInfo().GetId()] = true
+	}
+
+	events := New(f).WatchPrograms(ctx, nil, 10*time.Millisecond, func(err error) {
+		t.Errorf("List failed: %v", err)
+	})
+
+	// The programs present at the start are reported as added.
+	for range loaded {
+		ev := nextEvent(t, events)
+		if id := ev.Program.GetKernelInfo().GetId(); ev.Type != ProgramAdded || !loaded[id] {
+			t.Fatalf("got %s event for program %d, want added for one of %v", ev.Type, id, loaded)
+		}
+	}
+
+	var removed uint32
+	for id := range loaded {
+		removed = id
+		break
+	}
+	if _, err := f.Unload(ctx, &gobpfman.UnloadRequest{Id: removed}); err != nil {
+		t.Fatal(err)
+	}
+	ev := nextEvent(t, events)
+	if id := ev.Program.GetKernelInfo().GetId(); ev.Type != ProgramRemoved || id != removed {
+		t.Fatalf("got %s event for program %d, want removed for %d", ev.Type, id, removed)
+	}
+
+	cancel()
+	select {
+	case ev, ok := <-events:
+		if ok {
+			t.Fatalf("got %s event after cancel, want the channel closed", ev.Type)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("events channel not closed after cancel")
+	}
+}
@@ -27,6 +27,7 @@ type BpfmanClient interface {
 	List(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
 	PullBytecode(ctx context.Context, in *PullBytecodeRequest, opts ...grpc.CallOption) (*PullBytecodeResponse, error)
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
 }
 
 type bpfmanClient struct {
@@ -82,6 +83,15 @@ func (c *bpfmanClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.Cal
 	return out, nil
 }
 
+func (c *bpfmanClient) Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error) {
+	out := new(VersionResponse)
+	err := c.cc.Invoke(ctx, "/bpfman.v1.Bpfman/Version", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BpfmanServer is the server API for Bpfman service.
 // All implementations must embed UnimplementedBpfmanServer
 // for forward compatibility
@@ -91,6 +101,7 @@ type BpfmanServer interface {
 	List(context.Context, *ListRequest) (*ListResponse, error)
 	PullBytecode(context.Context, *PullBytecodeRequest) (*PullBytecodeResponse, error)
 	Get(context.Context, *GetRequest) (*GetResponse, error)
+	Version(context.Context, *VersionRequest) (*VersionResponse, error)
 	mustEmbedUnimplementedBpfmanServer()
 }
 
@@ -113,6 +124,9 @@ func (UnimplementedBpfmanServer) PullBytecode(context.Context, *PullBytecodeRequ
 func (UnimplementedBpfmanServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
 }
+func (UnimplementedBpfmanServer) Version(context.Context, *VersionRequest) (*VersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Version not implemented")
+}
 func (UnimplementedBpfmanServer) mustEmbedUnimplementedBpfmanServer() {}
 
 // UnsafeBpfmanServer may be embedded to opt out of forward compatibility for this service.
@@ -216,6 +230,24 @@ func _Bpfman_Get_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Bpfman_Version_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BpfmanServer).Version(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/bpfman.v1.Bpfman/Version",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BpfmanServer).Version(ctx, req.(*VersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Bpfman_ServiceDesc is the grpc.ServiceDesc for Bpfman service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -243,6 +275,10 @@ var Bpfman_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Get",
 			Handler:    _Bpfman_Get_Handler,
 		},
+		{
+			MethodName: "Version",
+			Handler:    _Bpfman_Version_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bpfman.proto",
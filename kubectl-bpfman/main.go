@@ -0,0 +1,105 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// kubectl-bpfman is a kubectl plugin that joins *Program, BpfProgram and node
+// data from the bpfman-operator API into a single human-readable view.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	bpfmanclientset "github.com/bpfman/bpfman-operator/pkg/client/clientset"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const usageText = `kubectl bpfman [--kubeconfig PATH] <command>
+
+Commands:
+  get programs           List all *Programs with the number of nodes they are loaded on.
+  status <kind>/<name>   Show the per-node state of a *Program, e.g. xdp/go-xdp-counter-example.
+  logs <kind>/<name>     Show the failed condition of the nodes where a *Program isn't
+                         loaded.
+  nodes                  Show the readiness of every node and its number of loaded and
+                         failed programs.
+
+Kinds: %s
+`
+
+func usage() {
+	fmt.Fprintf(flag.CommandLine.Output(), usageText, strings.Join(kindNames(), ", "))
+	flag.PrintDefaults()
+}
+
+func newClientsets(kubeconfig string) (bpfmanclientset.Interface, kubernetes.Interface, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if kubeconfig != "" {
+		rules.ExplicitPath = kubeconfig
+	}
+
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules,
+		&clientcmd.ConfigOverrides{}).ClientConfig()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load kubeconfig: %v", err)
+	}
+	cs, err := bpfmanclientset.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	kube, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cs, kube, nil
+}
+
+func run(ctx context.Context, cs bpfmanclientset.Interface, kube kubernetes.Interface, args []string) error {
+	switch {
+	case len(args) == 2 && args[0] == "get" && args[1] == "programs":
+		return getPrograms(ctx, cs, os.Stdout)
+	case len(args) == 2 && args[0] == "status":
+		return programStatus(ctx, cs, os.Stdout, args[1])
+	case len(args) == 2 && args[0] == "logs":
+		return programLogs(ctx, cs, os.Stdout, args[1])
+	case len(args) == 1 && args[0] == "nodes":
+		return nodes(ctx, cs, kube, os.Stdout)
+	default:
+		flag.Usage()
+		return fmt.Errorf("invalid command: %s", strings.Join(args, " "))
+	}
+}
+
+func main() {
+	kubeconfig := flag.String("kubeconfig", "",
+		"Path to the kubeconfig file. Defaults to $KUBECONFIG or ~/.kube/config.")
+	flag.Usage = usage
+	flag.Parse()
+
+	cs, kube, err := newClientsets(*kubeconfig)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := run(context.Background(), cs, kube, flag.Args()); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
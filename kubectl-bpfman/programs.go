@@ -0,0 +1,335 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	bpfmaniov1alpha1 "github.com/bpfman/bpfman-operator/apis/v1alpha1"
+	bpfmanclientset "github.com/bpfman/bpfman-operator/pkg/client/clientset"
+	bpfmanv1alpha1 "github.com/bpfman/bpfman-operator/pkg/client/clientset/typed/apis/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// Must match the labels the bpfman-operator agent sets on BpfPrograms.
+	bpfProgramOwnerLabel = "bpfman.io/ownedByProgram"
+	k8sHostLabel         = "kubernetes.io/hostname"
+)
+
+// program is the kind independent view of a *Program.
+type program struct {
+	Kind         string
+	Name         string
+	FunctionName string
+	Conditions   []metav1.Condition
+}
+
+// programObject holds the fields every *Program kind has in common.
+type programObject struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              bpfmaniov1alpha1.BpfProgramCommon       `json:"spec"`
+	Status            bpfmaniov1alpha1.BpfProgramStatusCommon `json:"status,omitempty"`
+}
+
+// programKind is a *Program kind. Its name is also the type the agent sets in
+// the spec of the kind's BpfPrograms.
+type programKind struct {
+	name string
+	list func(ctx context.Context, c bpfmanv1alpha1.BpfmanV1alpha1Interface) ([]program, error)
+}
+
+// kind returns the programKind listed through the typed client returned by
+// client, e.g. BpfmanV1alpha1Interface.XdpPrograms.
+func kind[L runtime.Object, C interface {
+	List(ctx context.Context, opts metav1.ListOptions) (L, error)
+}](name string, client func(bpfmanv1alpha1.BpfmanV1alpha1Interface) C) programKind {
+	list := func(ctx context.Context, c bpfmanv1alpha1.BpfmanV1alpha1Interface) ([]program, error) {
+		l, err := client(c).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+		items, err := meta.ExtractList(l)
+		if err != nil {
+			return nil, err
+		}
+
+		var progs []program
+		for _, item := range items {
+			u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(item)
+			if err != nil {
+				return nil, err
+			}
+			var obj programObject
+			if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u, &obj); err != nil {
+				return nil, err
+			}
+			progs = append(progs, program{name, obj.Name, obj.Spec.BpfFunctionName, obj.Status.Conditions})
+		}
+		return progs, nil
+	}
+	return programKind{name, list}
+}
+
+var programKinds = []programKind{
+	kind("xdp", bpfmanv1alpha1.BpfmanV1alpha1Interface.XdpPrograms),
+	kind("tc", bpfmanv1alpha1.BpfmanV1alpha1Interface.TcPrograms),
+	kind("tracepoint", bpfmanv1alpha1.BpfmanV1alpha1Interface.TracepointPrograms),
+	kind("kprobe", bpfmanv1alpha1.BpfmanV1alpha1Interface.KprobePrograms),
+	kind("uprobe", bpfmanv1alpha1.BpfmanV1alpha1Interface.UprobePrograms),
+	kind("fentry", bpfmanv1alpha1.BpfmanV1alpha1Interface.FentryPrograms),
+	kind("fexit", bpfmanv1alpha1.BpfmanV1alpha1Interface.FexitPrograms),
+}
+
+func kindNames() []string {
+	var names []string
+	for _, k := range programKinds {
+		names = append(names, k.name)
+	}
+	return names
+}
+
+// findProgram looks up a *Program given as "<kind>/<name>".
+func findProgram(ctx context.Context, cs bpfmanclientset.Interface, ref string) (program, error) {
+	kind, name, ok := strings.Cut(ref, "/")
+	if !ok {
+		return program{}, fmt.Errorf("program must be given as <kind>/<name>, got %q", ref)
+	}
+
+	for _, k := range programKinds {
+		if k.name != kind {
+			continue
+		}
+		progs, err := k.list(ctx, cs.BpfmanV1alpha1())
+		if err != nil {
+			return program{}, fmt.Errorf("failed to list %s programs: %v", kind, err)
+		}
+		for _, p := range progs {
+			if p.Name == name {
+				return p, nil
+			}
+		}
+		return program{}, fmt.Errorf("%s program %q not found", kind, name)
+	}
+	return program{}, fmt.Errorf("unknown program kind %q, valid kinds are %s", kind, strings.Join(kindNames(), ", "))
+}
+
+// bpfProgramsFor returns the per-node BpfPrograms of p. The owner label only
+// holds the *Program name, which is unique per kind, so the BpfPrograms are
+// also matched on the type the agent sets from the owner's kind.
+func bpfProgramsFor(ctx context.Context, cs bpfmanclientset.Interface, p program) ([]bpfmaniov1alpha1.BpfProgram, error) {
+	l, err := cs.BpfmanV1alpha1().BpfPrograms().List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", bpfProgramOwnerLabel, p.Name),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list bpfPrograms for %s/%s: %v", p.Kind, p.Name, err)
+	}
+
+	var items []bpfmaniov1alpha1.BpfProgram
+	for _, bpfProg := range l.Items {
+		if bpfProg.Spec.Type == p.Kind {
+			items = append(items, bpfProg)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Labels[k8sHostLabel] < items[j].Labels[k8sHostLabel]
+	})
+	return items, nil
+}
+
+// latestCondition returns the most recent condition, which the agent keeps
+// first.
+func latestCondition(conditions []metav1.Condition) metav1.Condition {
+	if len(conditions) == 0 {
+		return metav1.Condition{Type: string(bpfmaniov1alpha1.BpfProgCondNone)}
+	}
+	return conditions[0]
+}
+
+func isLoaded(bpfProg bpfmaniov1alpha1.BpfProgram) bool {
+	return latestCondition(bpfProg.Status.Conditions).Type == string(bpfmaniov1alpha1.BpfProgCondLoaded)
+}
+
+// isFailed reports whether the agent tried and failed to get bpfProg into
+// its desired state.
+func isFailed(bpfProg bpfmaniov1alpha1.BpfProgram) bool {
+	switch bpfmaniov1alpha1.BpfProgramConditionType(latestCondition(bpfProg.Status.Conditions).Type) {
+	case bpfmaniov1alpha1.BpfProgCondLoaded, bpfmaniov1alpha1.BpfProgCondNotSelected,
+		bpfmaniov1alpha1.BpfProgCondUnloaded, bpfmaniov1alpha1.BpfProgCondNone:
+		return false
+	default:
+		return true
+	}
+}
+
+func getPrograms(ctx context.Context, cs bpfmanclientset.Interface, out io.Writer) error {
+	l, err := cs.BpfmanV1alpha1().BpfPrograms().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list bpfPrograms: %v", err)
+	}
+	// BpfPrograms are counted per kind and name, as *Programs of different
+	// kinds may share a name.
+	total := map[string]int{}
+	loaded := map[string]int{}
+	for _, bpfProg := range l.Items {
+		owner := bpfProg.Spec.Type + "/" + bpfProg.Labels[bpfProgramOwnerLabel]
+		total[owner]++
+		if isLoaded(bpfProg) {
+			loaded[owner]++
+		}
+	}
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "KIND\tNAME\tBPF FUNCTION\tSTATUS\tNODES LOADED")
+
+	for _, k := range programKinds {
+		progs, err := k.list(ctx, cs.BpfmanV1alpha1())
+		if err != nil {
+			return fmt.Errorf("failed to list %s programs: %v", k.name, err)
+		}
+		for _, p := range progs {
+			owner := p.Kind + "/" + p.Name
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d/%d\n", p.Kind, p.Name, p.FunctionName,
+				latestCondition(p.Conditions).Type, loaded[owner], total[owner])
+		}
+	}
+	return w.Flush()
+}
+
+func programStatus(ctx context.Context, cs bpfmanclientset.Interface, out io.Writer, ref string) error {
+	p, err := findProgram(ctx, cs, ref)
+	if err != nil {
+		return err
+	}
+	bpfProgs, err := bpfProgramsFor(ctx, cs, p)
+	if err != nil {
+		return err
+	}
+
+	cond := latestCondition(p.Conditions)
+	fmt.Fprintf(out, "Kind:         %s\n", p.Kind)
+	fmt.Fprintf(out, "Name:         %s\n", p.Name)
+	fmt.Fprintf(out, "BPF Function: %s\n", p.FunctionName)
+	fmt.Fprintf(out, "Status:       %s\n", cond.Type)
+	if cond.Message != "" {
+		fmt.Fprintf(out, "Message:      %s\n", cond.Message)
+	}
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tBPFPROGRAM\tCONDITION\tREASON\tCREATED")
+	for _, bpfProg := range bpfProgs {
+		c := latestCondition(bpfProg.Status.Conditions)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", bpfProg.Labels[k8sHostLabel], bpfProg.Name,
+			c.Type, c.Reason, bpfProg.CreationTimestamp.Format("2006-01-02 15:04:05"))
+	}
+	return w.Flush()
+}
+
+func programLogs(ctx context.Context, cs bpfmanclientset.Interface, out io.Writer, ref string) error {
+	p, err := findProgram(ctx, cs, ref)
+	if err != nil {
+		return err
+	}
+	bpfProgs, err := bpfProgramsFor(ctx, cs, p)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, bpfProg := range bpfProgs {
+		if !isFailed(bpfProg) {
+			continue
+		}
+		failed++
+		c := latestCondition(bpfProg.Status.Conditions)
+		fmt.Fprintf(out, "==> %s (%s): %s <==\n%s\n\n", bpfProg.Labels[k8sHostLabel], bpfProg.Name,
+			c.Type, c.Message)
+	}
+	if failed == 0 {
+		fmt.Fprintf(out, "%s has no failed nodes\n", ref)
+	}
+	return nil
+}
+
+// nodeReady returns the readiness of node as kubectl shows it.
+func nodeReady(node corev1.Node) string {
+	for _, c := range node.Status.Conditions {
+		if c.Type == corev1.NodeReady && c.Status == corev1.ConditionTrue {
+			return "Ready"
+		}
+	}
+	return "NotReady"
+}
+
+func nodes(ctx context.Context, cs bpfmanclientset.Interface, kube kubernetes.Interface, out io.Writer) error {
+	nodeList, err := kube.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list nodes: %v", err)
+	}
+	l, err := cs.BpfmanV1alpha1().BpfPrograms().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to list bpfPrograms: %v", err)
+	}
+
+	type nodeCounts struct {
+		ready                 string
+		total, loaded, failed int
+	}
+	counts := map[string]*nodeCounts{}
+	for _, node := range nodeList.Items {
+		counts[node.Name] = &nodeCounts{ready: nodeReady(node)}
+	}
+	for _, bpfProg := range l.Items {
+		node := bpfProg.Labels[k8sHostLabel]
+		// A deleted node's BpfPrograms aren't removed with it, so show them
+		// as well.
+		if counts[node] == nil {
+			counts[node] = &nodeCounts{ready: "NotFound"}
+		}
+		counts[node].total++
+		if isLoaded(bpfProg) {
+			counts[node].loaded++
+		}
+		if isFailed(bpfProg) {
+			counts[node].failed++
+		}
+	}
+
+	var names []string
+	for node := range counts {
+		names = append(names, node)
+	}
+	sort.Strings(names)
+
+	w := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(w, "NODE\tSTATUS\tBPFPROGRAMS\tLOADED\tFAILED")
+	for _, node := range names {
+		c := counts[node]
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", node, c.ready, c.total, c.loaded, c.failed)
+	}
+	return w.Flush()
+}
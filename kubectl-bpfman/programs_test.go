@@ -0,0 +1,172 @@
+/*
+Copyright 2024.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	bpfmaniov1alpha1 "github.com/bpfman/bpfman-operator/apis/v1alpha1"
+	bpfmanfake "github.com/bpfman/bpfman-operator/pkg/client/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+)
+
+func xdpProgram(name string) *bpfmaniov1alpha1.XdpProgram {
+	p := &bpfmaniov1alpha1.XdpProgram{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	p.Spec.BpfFunctionName = "xdp_" + name
+	return p
+}
+
+func tcProgram(name string) *bpfmaniov1alpha1.TcProgram {
+	p := &bpfmaniov1alpha1.TcProgram{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	p.Spec.BpfFunctionName = "tc_" + name
+	return p
+}
+
+// bpfProgram returns the BpfProgram the agent creates on node for the
+// *Program of type progType named owner.
+func bpfProgram(progType, owner, node string, cond bpfmaniov1alpha1.BpfProgramConditionType) *bpfmaniov1alpha1.BpfProgram {
+	return &bpfmaniov1alpha1.BpfProgram{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   owner + "-" + progType + "-" + node,
+			Labels: map[string]string{bpfProgramOwnerLabel: owner, k8sHostLabel: node},
+		},
+		Spec: bpfmaniov1alpha1.BpfProgramSpec{Type: progType},
+		Status: bpfmaniov1alpha1.BpfProgramStatus{
+			Conditions: []metav1.Condition{{Type: string(cond)}},
+		},
+	}
+}
+
+func node(name string, ready corev1.ConditionStatus) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: ready}},
+		},
+	}
+}
+
+// sharedName has an xdp and a tc *Program with the same name, loaded on
+// different nodes.
+func sharedName() []runtime.Object {
+	return []runtime.Object{
+		xdpProgram("counter"),
+		tcProgram("counter"),
+		bpfProgram("xdp", "counter", "node-a", bpfmaniov1alpha1.BpfProgCondLoaded),
+		bpfProgram("tc", "counter", "node-a", bpfmaniov1alpha1.BpfProgCondNotLoaded),
+		bpfProgram("tc", "counter", "node-b", bpfmaniov1alpha1.BpfProgCondLoaded),
+	}
+}
+
+// rows returns the fields of each line of tabwriter output after the header.
+func rows(out string) [][]string {
+	var rows [][]string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n")[1:] {
+		rows = append(rows, strings.Fields(line))
+	}
+	return rows
+}
+
+func TestGetPrograms(t *testing.T) {
+	cs := bpfmanfake.NewSimpleClientset(sharedName()...)
+
+	var out bytes.Buffer
+	if err := getPrograms(context.Background(), cs, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"xdp", "counter", "xdp_counter", "None", "1/1"},
+		{"tc", "counter", "tc_counter", "None", "1/2"},
+	}
+	if got := rows(out.String()); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("get programs printed %q, want %q", got, want)
+	}
+}
+
+func TestProgramStatus(t *testing.T) {
+	cs := bpfmanfake.NewSimpleClientset(sharedName()...)
+
+	tests := []struct {
+		ref       string
+		wantNodes []string
+	}{
+		{ref: "xdp/counter", wantNodes: []string{"node-a"}},
+		{ref: "tc/counter", wantNodes: []string{"node-a", "node-b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			p, err := findProgram(context.Background(), cs, tt.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			bpfProgs, err := bpfProgramsFor(context.Background(), cs, p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var nodes []string
+			for _, bpfProg := range bpfProgs {
+				nodes = append(nodes, bpfProg.Labels[k8sHostLabel])
+			}
+			if !slices.Equal(nodes, tt.wantNodes) {
+				t.Errorf("BpfPrograms on %v, want %v", nodes, tt.wantNodes)
+			}
+		})
+	}
+}
+
+func TestFindProgramErrors(t *testing.T) {
+	cs := bpfmanfake.NewSimpleClientset(sharedName()...)
+
+	for _, ref := range []string{"counter", "kprobe/counter", "lsm/counter"} {
+		if _, err := findProgram(context.Background(), cs, ref); err == nil {
+			t.Errorf("findProgram(%q) succeeded", ref)
+		}
+	}
+}
+
+func TestNodes(t *testing.T) {
+	cs := bpfmanfake.NewSimpleClientset(sharedName()...)
+	// node-b has been deleted, node-c and node-d have no BpfPrograms.
+	kube := kubefake.NewSimpleClientset(
+		node("node-a", corev1.ConditionTrue),
+		node("node-c", corev1.ConditionTrue),
+		node("node-d", corev1.ConditionUnknown),
+	)
+
+	var out bytes.Buffer
+	if err := nodes(context.Background(), cs, kube, &out); err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][]string{
+		{"node-a", "Ready", "2", "1", "1"},
+		{"node-b", "NotFound", "1", "1", "0"},
+		{"node-c", "Ready", "0", "0", "0"},
+		{"node-d", "NotReady", "0", "0", "0"},
+	}
+	if got := rows(out.String()); !slices.EqualFunc(got, want, slices.Equal) {
+		t.Errorf("nodes printed %q, want %q", got, want)
+	}
+}